@@ -0,0 +1,82 @@
+// Package backend provides the ethereum backends unibot runs against. Besides a regular
+// ethclient these include go-ethereum's simulated backend and a record/replay backend,
+// which allow the clients to be tested deterministically without network access
+package backend
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Backend is the subset of the ethereum client api used by unibot. Along with the
+// contract calls, transactions and log subscriptions of bind.ContractBackend it
+// provides access to the current block
+type Backend interface {
+	bind.ContractBackend
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Caller is implemented by backends that can make raw JSON-RPC calls, which are used for
+// methods ethclient doesn't support such as eth_feeHistory
+type Caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Client is an ethclient backend that can also make raw JSON-RPC calls
+type Client struct {
+	*ethclient.Client
+	rc *rpc.Client
+}
+
+// NewClient returns an ethclient backend using the given RPC client
+func NewClient(rc *rpc.Client) *Client {
+	return &Client{Client: ethclient.NewClient(rc), rc: rc}
+}
+
+// CallContext makes a raw JSON-RPC call, decoding its result into result
+func (c *Client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.rc.CallContext(ctx, result, method, args...)
+}
+
+// Dial returns an ethclient backend connected to the given RPC endpoint
+func Dial(url string) (Backend, error) {
+	rc, err := rpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(rc), nil
+}
+
+// SimulatedChainID is the chain id used by go-ethereum's simulated backend
+var SimulatedChainID = big.NewInt(1337)
+
+// Simulated adapts go-ethereum's simulated backend to Backend
+type Simulated struct {
+	*backends.SimulatedBackend
+}
+
+// NewSimulated returns a backend using the given simulated chain
+func NewSimulated(sim *backends.SimulatedBackend) *Simulated {
+	return &Simulated{sim}
+}
+
+// BlockNumber returns the number of the most recently committed block
+func (s *Simulated) BlockNumber(ctx context.Context) (uint64, error) {
+	header, err := s.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+// ChainID returns the chain id of the simulated chain
+func (s *Simulated) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(SimulatedChainID), nil
+}
@@ -0,0 +1,26 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulated(t *testing.T) {
+	sim := NewSimulated(backends.NewSimulatedBackend(core.GenesisAlloc{}, 10000000))
+	defer sim.Close()
+	var b Backend = sim
+	block, err := b.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), block)
+	sim.Commit()
+	block, err = b.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), block)
+	chainID, err := sim.ChainID(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, SimulatedChainID, chainID)
+}
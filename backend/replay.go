@@ -0,0 +1,251 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Exchange is a JSON-RPC request and the response it received
+type Exchange struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is a JSON-RPC error response
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// jsonrpcMessage is a JSON-RPC request or response
+type jsonrpcMessage struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// key identifies the exchanges of a request by its method and parameters. Requests without
+// parameters may omit them or send null, so both are treated as an empty list
+func (e *Exchange) key() string {
+	var params bytes.Buffer
+	if err := json.Compact(&params, e.Params); err != nil || params.String() == "null" {
+		params.Reset()
+		params.WriteString("[]")
+	}
+	return e.Method + params.String()
+}
+
+// Recorder is an ethclient backend that captures its JSON-RPC traffic, which is
+// written to a fixture file for use with Replay when the recorder is closed.
+// Only HTTP endpoints are supported, so subscriptions can't be recorded
+type Recorder struct {
+	*Client
+
+	path string
+	mux  sync.Mutex
+	// exchanges are kept in the order the requests were sent
+	exchanges []Exchange
+}
+
+// Record returns a backend connected to the given HTTP RPC endpoint that records its traffic to path
+func Record(url, path string) (*Recorder, error) {
+	r := &Recorder{path: path}
+	rc, err := rpc.DialHTTPWithClient(url, &http.Client{Transport: r})
+	if err != nil {
+		return nil, err
+	}
+	r.Client = NewClient(rc)
+	return r, nil
+}
+
+// RoundTrip forwards the request and records the exchanges it contains
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	requests, _, err := decodeMessages(reqBody)
+	if err != nil {
+		return nil, err
+	}
+	responses, _, err := decodeMessages(respBody)
+	if err != nil {
+		// not a JSON-RPC response, so there is nothing to record
+		return resp, nil
+	}
+	byID := make(map[string]*jsonrpcMessage, len(responses))
+	for _, msg := range responses {
+		byID[string(msg.ID)] = msg
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for _, msg := range requests {
+		if res, ok := byID[string(msg.ID)]; ok {
+			r.exchanges = append(r.exchanges, Exchange{Method: msg.Method, Params: msg.Params, Result: res.Result, Error: res.Error})
+		}
+	}
+	return resp, nil
+}
+
+// Exchanges returns the exchanges recorded so far
+func (r *Recorder) Exchanges() []Exchange {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]Exchange(nil), r.exchanges...)
+}
+
+// Close writes the recorded exchanges to the fixture file and closes the connection
+func (r *Recorder) Close() error {
+	r.Client.Close()
+	data, err := json.MarshalIndent(r.Exchanges(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+// Replay returns an ethclient backend answering requests from the exchanges in a fixture file
+// written by a Recorder. Requests with the same method and parameters are answered with their
+// recorded responses in order, repeating the last once exhausted. Requests that were never
+// recorded fail with an error
+func Replay(path string) (Backend, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var exchanges []Exchange
+	if err := json.Unmarshal(data, &exchanges); err != nil {
+		return nil, err
+	}
+	return NewReplayer(exchanges), nil
+}
+
+// NewReplayer returns an ethclient backend answering requests from the given exchanges. See Replay
+func NewReplayer(exchanges []Exchange) Backend {
+	r := &replayer{responses: make(map[string][]Exchange)}
+	for _, exchange := range exchanges {
+		key := exchange.key()
+		r.responses[key] = append(r.responses[key], exchange)
+	}
+	// the endpoint is never dialled as the transport answers every request
+	rc, err := rpc.DialHTTPWithClient("http://replay.invalid", &http.Client{Transport: r})
+	if err != nil {
+		panic(err)
+	}
+	return NewClient(rc)
+}
+
+// replayer is an http transport answering JSON-RPC requests from recorded exchanges
+type replayer struct {
+	mux       sync.Mutex
+	responses map[string][]Exchange
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	requests, batch, err := decodeMessages(body)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*jsonrpcMessage, 0, len(requests))
+	for _, msg := range requests {
+		exchange, err := r.next(&Exchange{Method: msg.Method, Params: msg.Params})
+		res := &jsonrpcMessage{Version: "2.0", ID: msg.ID}
+		if err != nil {
+			res.Error = &RPCError{Code: -32601, Message: err.Error()}
+		} else if exchange.Error != nil {
+			res.Error = exchange.Error
+		} else {
+			res.Result = exchange.Result
+		}
+		responses = append(responses, res)
+	}
+	var data []byte
+	if batch {
+		data, err = json.Marshal(responses)
+	} else {
+		data, err = json.Marshal(responses[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+// next returns the next recorded response to the request
+func (r *replayer) next(req *Exchange) (Exchange, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	key := req.key()
+	responses := r.responses[key]
+	if len(responses) == 0 {
+		return Exchange{}, fmt.Errorf("no recorded response for %s %s", req.Method, req.Params)
+	}
+	if len(responses) > 1 {
+		r.responses[key] = responses[1:]
+	}
+	return responses[0], nil
+}
+
+// readBody reads the body and replaces it so it can be read again
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil {
+		return nil, nil
+	}
+	data, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// decodeMessages decodes a single or batch JSON-RPC message, reporting whether it was a batch
+func decodeMessages(data []byte) ([]*jsonrpcMessage, bool, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, false, errors.New("empty JSON-RPC message")
+	}
+	if data[0] == '[' {
+		var msgs []*jsonrpcMessage
+		return msgs, true, json.Unmarshal(data, &msgs)
+	}
+	var msg jsonrpcMessage
+	return []*jsonrpcMessage{&msg}, false, json.Unmarshal(data, &msg)
+}
@@ -0,0 +1,80 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newNode returns a fake JSON-RPC node whose block number increases with every request
+func newNode(t *testing.T) *httptest.Server {
+	var block uint64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpcMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		res := jsonrpcMessage{Version: "2.0", ID: req.ID}
+		switch req.Method {
+		case "eth_blockNumber":
+			block++
+			res.Result = json.RawMessage(`"0x` + string("0123456789abcdef"[block]) + `"`)
+		case "eth_getCode":
+			res.Result = json.RawMessage(`"0x6001"`)
+		default:
+			res.Error = &RPCError{Code: -32601, Message: "method not found"}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecordReplay(t *testing.T) {
+	node := newNode(t)
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	ctx := context.Background()
+	addr := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	recorder, err := Record(node.URL, path)
+	require.NoError(t, err)
+	for _, want := range []uint64{1, 2} {
+		block, err := recorder.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, block)
+	}
+	code, err := recorder.CodeAt(ctx, addr, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x01}, code)
+	_, err = recorder.ChainID(ctx)
+	require.Error(t, err)
+	require.Len(t, recorder.Exchanges(), 4)
+	require.NoError(t, recorder.Close())
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "eth_getCode")
+
+	replay, err := Replay(path)
+	require.NoError(t, err)
+	// responses are replayed in order, repeating the last
+	for _, want := range []uint64{1, 2, 2} {
+		block, err := replay.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, block)
+	}
+	code, err = replay.CodeAt(ctx, addr, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x01}, code)
+	// recorded errors are replayed, and requests that were never recorded fail
+	_, err = replay.CodeAt(ctx, common.Address{}, nil)
+	require.Error(t, err)
+	_, err = replay.(interface {
+		TransactionCount(context.Context, common.Hash) (uint, error)
+	}).TransactionCount(ctx, common.Hash{})
+	require.Error(t, err)
+}
@@ -2,46 +2,164 @@ package bclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
+	"github.com/bonedaddy/unibot/backend"
+	"github.com/bonedaddy/unibot/network"
 	"github.com/bonedaddy/unibot/uniswap"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
-// Client wraps ethclient and provides helper functions for interacting with uniswap
+// ErrUnsupported is returned when the backend the client was created with doesn't support an operation
+var ErrUnsupported = errors.New("operation not supported by backend")
+
+// ErrWrongChain is returned when dialing a node that is on a different chain than expected
+var ErrWrongChain = errors.New("node is on the wrong chain")
+
+// Client wraps an ethereum backend and provides helper functions for interacting with uniswap
 type Client struct {
-	ec *ethclient.Client
-	uc *uniswap.Client
+	ec  backend.Backend
+	uc  *uniswap.Client
+	net *network.Network
+
+	tmux   sync.RWMutex
+	tokens map[common.Address]*Token
+
+	pmux       sync.Mutex
+	portfolios map[common.Address]cachedPortfolio
+
+	// emux guards the cached ENS resolutions of names and reverse lookups of addresses
+	emux      sync.Mutex
+	names     map[string]ensRecord
+	addresses map[common.Address]ensRecord
 }
 
 // NewInfuraClient returns an eth client connected to infura
 func NewInfuraClient(token string, websockets bool) (*Client, error) {
-	var url string
-	if websockets {
-		url = InfuraWSURL + token
-	} else {
-		url = InfuraHTTPURL + token
+	return NewNetworkInfuraClient(network.Mainnet, token, websockets)
+}
+
+// NewNetworkInfuraClient returns an eth client connected to infura's endpoint for the given network
+func NewNetworkInfuraClient(net *network.Network, token string, websockets bool) (*Client, error) {
+	url, err := net.InfuraURL(token, websockets)
+	if err != nil {
+		return nil, err
 	}
-	return NewClient(url)
+	return Dial(url, net)
 }
 
-// NewClient returns an eth client connected to an RPC
+// NewClient returns an eth client connected to a mainnet RPC
 func NewClient(url string) (*Client, error) {
-	ec, err := ethclient.Dial(url)
+	return Dial(url, network.Mainnet)
+}
+
+// Dial returns an eth client connected to an RPC for the given network. The node's chain id
+// is checked against the network, returning ErrWrongChain if they don't match
+func Dial(url string, net *network.Network) (*Client, error) {
+	ec, err := backend.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	client := NewNetworkClient(ec, net)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	chainID, err := client.chainID(ctx)
 	if err != nil {
+		client.Close()
 		return nil, err
 	}
-	return &Client{ec, uniswap.NewClient(ec)}, nil
+	if chainID.Uint64() != net.ChainID {
+		client.Close()
+		return nil, fmt.Errorf("%w: expected %s (%d) but got chain id %s", ErrWrongChain, net.Name, net.ChainID, chainID)
+	}
+	return client, nil
+}
+
+// NewClientWithBackend returns a mainnet client using the given backend, such as an ethclient or
+// one of the simulated and replay backends. Operations the Backend interface doesn't cover,
+// such as TxSender, return ErrUnsupported if the backend does not implement them
+func NewClientWithBackend(ec backend.Backend) *Client {
+	return NewNetworkClient(ec, network.Mainnet)
+}
+
+// NewNetworkClient is like NewClientWithBackend but uses the given network's default exchange,
+// wrapped native token and stablecoins. The backend's chain id is not checked
+func NewNetworkClient(ec backend.Backend, net *network.Network) *Client {
+	return &Client{
+		ec:         ec,
+		uc:         uniswap.NewVenueClient(ec, net.DEX()),
+		net:        net,
+		tokens:     make(map[common.Address]*Token),
+		portfolios: make(map[common.Address]cachedPortfolio),
+		names:      make(map[string]ensRecord),
+		addresses:  make(map[common.Address]ensRecord),
+	}
 }
 
+// Backend returns the backend used by the client
+func (c *Client) Backend() backend.Backend { return c.ec }
+
+// Network returns the network the client is connected to
+func (c *Client) Network() *network.Network { return c.net }
+
 // CurrentBlock returns the current block known by the ethereum client
 func (c *Client) CurrentBlock() (uint64, error) {
 	return c.ec.BlockNumber(context.Background())
 }
 
+// CurrentHeader returns the header of the current block known by the ethereum client
+func (c *Client) CurrentHeader() (*types.Header, error) {
+	return c.ec.HeaderByNumber(context.Background(), nil)
+}
+
+// TxSender returns the address that signed the given transaction
+func (c *Client) TxSender(txHash, blockHash common.Hash, txIndex uint) (common.Address, error) {
+	ec, ok := c.ec.(interface {
+		TransactionByHash(context.Context, common.Hash) (*types.Transaction, bool, error)
+	})
+	if !ok {
+		return common.Address{}, ErrUnsupported
+	}
+	tx, _, err := ec.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return common.Address{}, err
+	}
+	// ethclient can use the sender cached by the node when it returned the transaction
+	if ec, ok := c.ec.(interface {
+		TransactionSender(context.Context, *types.Transaction, common.Hash, uint) (common.Address, error)
+	}); ok {
+		return ec.TransactionSender(context.Background(), tx, blockHash, txIndex)
+	}
+	return types.Sender(txSigner(tx), tx)
+}
+
+// txSigner returns the signer used to sign the given transaction
+func txSigner(tx *types.Transaction) types.Signer {
+	if tx.Protected() {
+		return types.NewEIP155Signer(tx.ChainId())
+	}
+	return types.HomesteadSigner{}
+}
+
 // Uniswap returns a uniswap client helper
 func (c *Client) Uniswap() *uniswap.Client { return c.uc }
 
 // Close terminates the blockchain connection
 func (c *Client) Close() {
-	c.ec.Close()
+	switch ec := c.ec.(type) {
+	case interface{ Close() }:
+		ec.Close()
+	case interface{ Close() error }:
+		ec.Close()
+	}
+}
+
+// GasPrice returns the gas price suggested by the ethereum client in wei
+func (c *Client) GasPrice() (*big.Int, error) {
+	return c.ec.SuggestGasPrice(context.Background())
 }
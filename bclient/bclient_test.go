@@ -1,38 +1,141 @@
-package bclient
+package bclient_test
 
 import (
-	"os"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/bonedaddy/unibot/backend"
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/bonedaddy/unibot/network"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 )
 
-var (
-	// my personal address
-	myAddress = common.HexToAddress("0x5a361A1dfd52538A158e352d21B5b622360a7C13")
-)
-
-func doSetup(t *testing.T) *Client {
-	infuraAPIKey := os.Getenv("INFURA_API_KEY")
-	if infuraAPIKey == "" {
-		t.Fatal("INFURA_API_KEY env var is empty")
-	}
-	client, err := NewInfuraClient(infuraAPIKey, false)
-	require.NoError(t, err)
-	return client
-
-}
-
 func TestBClient(t *testing.T) {
-	client := doSetup(t)
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	client := h.Client()
 	t.Cleanup(func() {
 		client.Close()
 	})
+	// ETH is worth 2000 DAI and 2000 USDC
+	_, err = h.SeedPools(
+		harness.Pool{TokenA: bclient.WETHTokenAddress, TokenB: bclient.DAITokenAddress, AmountA: utils.ToWei(int64(100), 18), AmountB: utils.ToWei(int64(200000), 18)},
+		harness.Pool{TokenA: bclient.WETHTokenAddress, TokenB: bclient.USDCTokenAddress, AmountA: utils.ToWei(int64(100), 18), AmountB: utils.ToWei(int64(200000), 6)},
+	)
+	require.NoError(t, err)
 	t.Run("Misc", func(t *testing.T) {
-		_, err := client.CurrentBlock()
+		block, err := client.CurrentBlock()
 		require.NoError(t, err)
+		require.NotZero(t, block)
 		require.NotNil(t, client.Uniswap())
 	})
+	t.Run("Trade", func(t *testing.T) {
+		uc := client.Uniswap()
+		// there is no DAI/USDC pair so the swap is routed through WETH
+		path, err := uc.SwapPath(bclient.DAITokenAddress, bclient.USDCTokenAddress, bclient.WETHTokenAddress)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{bclient.DAITokenAddress, bclient.WETHTokenAddress, bclient.USDCTokenAddress}, path)
+		amountIn := utils.ToWei(int64(100), 18)
+		amounts, err := uc.GetAmountsOut(amountIn, path)
+		require.NoError(t, err)
+		require.Len(t, amounts, 3)
+		amountOut := amounts[2]
+		// a little under 100 USDC after two fees
+		usdc, _ := utils.ToDecimal(amountOut, 6).Float64()
+		require.InDelta(t, 99.3, usdc, 0.1)
+
+		send := func(to common.Address, data []byte) {
+			tx, err := client.Send(h.Key, bclient.Call{To: to, Data: data})
+			require.NoError(t, err)
+			h.Backend.Commit()
+			receipt, err := h.Backend.TransactionReceipt(context.Background(), tx.Hash())
+			require.NoError(t, err)
+			require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		}
+		require.NoError(t, h.Mint(bclient.DAITokenAddress, h.Auth.From, amountIn))
+		data, err := uniswap.ApproveCalldata(uc.Venue().Router, amountIn)
+		require.NoError(t, err)
+		send(bclient.DAITokenAddress, data)
+		data, err = uniswap.SwapCalldata(uniswap.SwapParams{
+			Path:         path,
+			AmountIn:     amountIn,
+			AmountOutMin: uniswap.AmountOutMin(amountOut, 50),
+			To:           h.Auth.From,
+			Deadline:     time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		// the simulated swap receives the quoted amount
+		result, err := client.Simulate(bclient.Call{From: h.Auth.From, To: uc.Venue().Router, Data: data})
+		require.NoError(t, err)
+		simulated, err := uniswap.UnpackSwapAmounts(result)
+		require.NoError(t, err)
+		require.Equal(t, amountOut, simulated[2])
+		send(uc.Venue().Router, data)
+		balance, err := h.BalanceOf(bclient.USDCTokenAddress, h.Auth.From)
+		require.NoError(t, err)
+		require.Equal(t, amountOut, balance)
+	})
+}
 
+func TestDial(t *testing.T) {
+	// a node that only answers eth_chainId, reporting polygon
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "eth_chainId", req.Method)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  "0x89",
+		}))
+	}))
+	t.Cleanup(node.Close)
+	_, err := bclient.NewClient(node.URL)
+	require.True(t, errors.Is(err, bclient.ErrWrongChain))
+	client, err := bclient.Dial(node.URL, network.Polygon)
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, network.Polygon, client.Network())
+	require.Equal(t, network.Polygon.DEX(), client.Uniswap().Venue())
+}
+
+func TestGasFees(t *testing.T) {
+	gwei := func(n int64) string { return hexutil.EncodeBig(big.NewInt(n * 1e9)) }
+	history, err := json.Marshal(map[string]interface{}{
+		"oldestBlock":   "0x64",
+		"baseFeePerGas": []string{gwei(30), gwei(31), gwei(32), gwei(33)},
+		// the empty block's zero rewards are ignored
+		"gasUsedRatio": []float64{0.5, 0, 0.9},
+		"reward": [][]string{
+			{gwei(1), gwei(2), gwei(5)},
+			{gwei(0), gwei(0), gwei(0)},
+			{gwei(1), gwei(3), gwei(7)},
+		},
+	})
+	require.NoError(t, err)
+	client := bclient.NewClientWithBackend(backend.NewReplayer([]backend.Exchange{
+		{Method: "eth_feeHistory", Params: json.RawMessage(`["0x14","latest",[10,50,90]]`), Result: history},
+	}))
+	fees, err := client.GasFees()
+	require.NoError(t, err)
+	require.Equal(t, uint64(102), fees.Block)
+	require.Equal(t, big.NewInt(33e9), fees.BaseFee)
+	require.Equal(t, big.NewInt(34e9), fees.Slow())
+	require.Equal(t, big.NewInt(36e9), fees.Standard())
+	require.Equal(t, big.NewInt(40e9), fees.Fast())
 }
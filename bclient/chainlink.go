@@ -0,0 +1,36 @@
+package bclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	chainlinkaggregator "github.com/bonedaddy/unibot/bindings/chainlink/aggregator"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChainlinkPrice returns the latest answer reported by the given chainlink aggregator,
+// adjusted for the aggregator's decimals, along with the time it was last updated
+func (c *Client) ChainlinkPrice(feed common.Address) (float64, time.Time, error) {
+	caller, err := chainlinkaggregator.NewChainlinkaggregatorCaller(feed, c.ec)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	decimals, err := caller.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	round, err := caller.LatestRoundData(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if round.Answer.Sign() <= 0 {
+		return 0, time.Time{}, errors.New("invalid chainlink answer")
+	}
+	price, _ := utils.ToDecimal(round.Answer, int(decimals)).Float64()
+	return price, time.Unix(round.UpdatedAt.Int64(), 0), nil
+}
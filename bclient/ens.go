@@ -0,0 +1,149 @@
+package bclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ensregistry "github.com/bonedaddy/unibot/bindings/ens/registry"
+	ensresolver "github.com/bonedaddy/unibot/bindings/ens/resolver"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ENSTTL is how long resolved names and addresses are cached for
+const ENSTTL = time.Minute * 10
+
+var (
+	// ErrNoENS is returned when resolving names on a network without an ENS registry
+	ErrNoENS = errors.New("ens is not deployed on the network")
+	// ErrNameNotFound is returned when an ENS name has no resolver or address
+	ErrNameNotFound = errors.New("ens name not found")
+	// ErrInvalidAddress is returned when a string is neither a hex address nor an ENS name
+	ErrInvalidAddress = errors.New("invalid address")
+)
+
+// ensRecord is a cached forward or reverse resolution. Names that don't resolve are cached too,
+// with a zero address or empty name
+type ensRecord struct {
+	address common.Address
+	name    string
+	fetched time.Time
+}
+
+// Namehash returns the ENS node of the name as defined by EIP-137. Names are lowercased, which
+// normalizes ascii names but not the full UTS-46 mapping
+func Namehash(name string) common.Hash {
+	var node common.Hash
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
+
+// ResolveAddress returns the address of a string that is either a hex address or an ENS name
+func (c *Client) ResolveAddress(address string) (common.Address, error) {
+	switch {
+	case utils.IsValidAddress(address):
+		return common.HexToAddress(address), nil
+	case utils.IsENSName(address):
+		return c.ResolveName(address)
+	default:
+		return common.Address{}, fmt.Errorf("%w: %s", ErrInvalidAddress, address)
+	}
+}
+
+// ResolveName returns the address the ENS name resolves to, or ErrNameNotFound if it doesn't
+// resolve. Resolutions are cached for ENSTTL
+func (c *Client) ResolveName(name string) (common.Address, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	c.emux.Lock()
+	record, ok := c.names[name]
+	c.emux.Unlock()
+	if !ok || time.Since(record.fetched) >= ENSTTL {
+		address, err := c.resolve(Namehash(name))
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		record = ensRecord{address: address, name: name, fetched: time.Now()}
+		c.emux.Lock()
+		c.names[name] = record
+		c.emux.Unlock()
+	}
+	if record.address == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrNameNotFound, name)
+	}
+	return record.address, nil
+}
+
+// LookupAddress returns the primary ENS name of the address, or an empty string if it has none.
+// Names are only returned if they resolve back to the address, as anyone can set a reverse
+// record to any name. Lookups are cached for ENSTTL
+func (c *Client) LookupAddress(address common.Address) (string, error) {
+	c.emux.Lock()
+	record, ok := c.addresses[address]
+	c.emux.Unlock()
+	if ok && time.Since(record.fetched) < ENSTTL {
+		return record.name, nil
+	}
+	node := Namehash(strings.ToLower(address.Hex()[2:]) + ".addr.reverse")
+	resolver, err := c.resolver(node)
+	if err != nil {
+		return "", err
+	}
+	var name string
+	if resolver != (common.Address{}) {
+		caller, err := ensresolver.NewEnsresolverCaller(resolver, c.ec)
+		if err != nil {
+			return "", err
+		}
+		if name, err = caller.Name(&bind.CallOpts{}, node); err != nil {
+			return "", fmt.Errorf("failed to lookup %s: %w", address.Hex(), err)
+		}
+	}
+	if name != "" {
+		resolved, err := c.ResolveName(name)
+		if errors.Is(err, ErrNameNotFound) || (err == nil && resolved != address) {
+			name = ""
+		} else if err != nil {
+			return "", err
+		}
+	}
+	c.emux.Lock()
+	c.addresses[address] = ensRecord{address: address, name: name, fetched: time.Now()}
+	c.emux.Unlock()
+	return name, nil
+}
+
+// resolve returns the address set for the node, or the zero address if it has none
+func (c *Client) resolve(node common.Hash) (common.Address, error) {
+	resolver, err := c.resolver(node)
+	if err != nil || resolver == (common.Address{}) {
+		return common.Address{}, err
+	}
+	caller, err := ensresolver.NewEnsresolverCaller(resolver, c.ec)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return caller.Addr(&bind.CallOpts{}, node)
+}
+
+// resolver returns the resolver of the node from the registry, or the zero address if it has none
+func (c *Client) resolver(node common.Hash) (common.Address, error) {
+	if c.net.ENSRegistry == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrNoENS, c.net.Name)
+	}
+	registry, err := ensregistry.NewEnsregistryCaller(c.net.ENSRegistry, c.ec)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return registry.Resolver(&bind.CallOpts{}, node)
+}
@@ -0,0 +1,132 @@
+package bclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/bonedaddy/unibot/backend"
+	ensregistry "github.com/bonedaddy/unibot/bindings/ens/registry"
+	ensresolver "github.com/bonedaddy/unibot/bindings/ens/resolver"
+	"github.com/bonedaddy/unibot/network"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// my personal address
+	myAddress = common.HexToAddress("0x5a361A1dfd52538A158e352d21B5b622360a7C13")
+)
+
+// fakeENS is a backend answering calls to the ENS registry and a single resolver
+type fakeENS struct {
+	backend.Backend
+	resolver common.Address
+	// addrs and names are the addr and name records of the resolver
+	addrs map[common.Hash]common.Address
+	names map[common.Hash]string
+	calls int
+}
+
+func (f *fakeENS) CodeAt(ctx context.Context, account common.Address, number *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeENS) CallContract(ctx context.Context, msg ethereum.CallMsg, number *big.Int) ([]byte, error) {
+	f.calls++
+	contractABI := ensresolver.EnsresolverABI
+	if *msg.To == network.Mainnet.ENSRegistry {
+		contractABI = ensregistry.EnsregistryABI
+	} else if *msg.To != f.resolver {
+		return nil, errors.New("unexpected call")
+	}
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, err
+	}
+	method, err := parsed.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	var node common.Hash
+	copy(node[:], msg.Data[4:])
+	switch method.Name {
+	case "resolver":
+		// only nodes with records have a resolver
+		_, addr := f.addrs[node]
+		_, name := f.names[node]
+		if addr || name {
+			return method.Outputs.Pack(f.resolver)
+		}
+		return method.Outputs.Pack(common.Address{})
+	case "addr":
+		return method.Outputs.Pack(f.addrs[node])
+	default:
+		return method.Outputs.Pack(f.names[node])
+	}
+}
+
+func TestNamehash(t *testing.T) {
+	require.Equal(t, common.Hash{}, Namehash(""))
+	require.Equal(t, common.HexToHash("0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"), Namehash("eth"))
+	require.Equal(t, common.HexToHash("0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"), Namehash("foo.eth"))
+	require.Equal(t, Namehash("foo.eth"), Namehash("Foo.ETH"))
+}
+
+func TestENS(t *testing.T) {
+	alice := common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	mallory := common.HexToAddress("0x0000000000000000000000000000000000000bad")
+	reverse := func(address common.Address) common.Hash {
+		return Namehash(strings.ToLower(address.Hex()[2:]) + ".addr.reverse")
+	}
+	ens := &fakeENS{
+		resolver: common.HexToAddress("0x0000000000000000000000000000000000001234"),
+		addrs:    map[common.Hash]common.Address{Namehash("alice.eth"): alice},
+		names: map[common.Hash]string{
+			reverse(alice): "alice.eth",
+			// anyone can claim any name in their reverse record
+			reverse(mallory): "alice.eth",
+		},
+	}
+	client := NewClientWithBackend(ens)
+
+	address, err := client.ResolveAddress("Alice.eth")
+	require.NoError(t, err)
+	require.Equal(t, alice, address)
+	address, err = client.ResolveAddress(myAddress.Hex())
+	require.NoError(t, err)
+	require.Equal(t, myAddress, address)
+	_, err = client.ResolveAddress("bob.eth")
+	require.True(t, errors.Is(err, ErrNameNotFound))
+	_, err = client.ResolveAddress("WETH/DAI")
+	require.True(t, errors.Is(err, ErrInvalidAddress))
+
+	name, err := client.LookupAddress(alice)
+	require.NoError(t, err)
+	require.Equal(t, "alice.eth", name)
+	// reverse records not resolving back to the address are ignored
+	name, err = client.LookupAddress(mallory)
+	require.NoError(t, err)
+	require.Empty(t, name)
+	name, err = client.LookupAddress(myAddress)
+	require.NoError(t, err)
+	require.Empty(t, name)
+
+	// resolutions are cached, including names that don't resolve
+	calls := ens.calls
+	_, err = client.ResolveName("alice.eth")
+	require.NoError(t, err)
+	_, err = client.ResolveName("bob.eth")
+	require.Error(t, err)
+	_, err = client.LookupAddress(mallory)
+	require.NoError(t, err)
+	require.Equal(t, calls, ens.calls)
+
+	// networks without ENS can't resolve names
+	_, err = NewNetworkClient(ens, network.Polygon).ResolveAddress("alice.eth")
+	require.True(t, errors.Is(err, ErrNoENS))
+}
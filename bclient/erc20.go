@@ -0,0 +1,42 @@
+package bclient
+
+import (
+	"github.com/bonedaddy/unibot/bindings/erc20"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Token contains the erc20 metadata needed to display token amounts
+type Token struct {
+	Address  common.Address
+	Symbol   string
+	Decimals int
+}
+
+// TokenInfo returns the symbol and decimals of the given erc20 token.
+// Token metadata never changes so results are cached for the lifetime of the client
+func (c *Client) TokenInfo(token common.Address) (*Token, error) {
+	c.tmux.RLock()
+	info, ok := c.tokens[token]
+	c.tmux.RUnlock()
+	if ok {
+		return info, nil
+	}
+	caller, err := erc20.NewErc20Caller(token, c.ec)
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := caller.Decimals(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := caller.Symbol(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	info = &Token{Address: token, Symbol: symbol, Decimals: int(decimals)}
+	c.tmux.Lock()
+	c.tokens[token] = info
+	c.tmux.Unlock()
+	return info, nil
+}
@@ -0,0 +1,125 @@
+package bclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/bonedaddy/unibot/backend"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SwapGasLimit is the gas used by a typical uniswap v2 swap
+const SwapGasLimit uint64 = 150000
+
+// feeHistoryBlocks is how many recent blocks priority fees are sampled from
+const feeHistoryBlocks = 20
+
+// feePercentiles are the percentiles of the priority fees paid in a block used for the slow,
+// standard and fast suggestions
+var feePercentiles = []float64{10, 50, 90}
+
+// GasFees are the fees suggested for the pending block, all in wei
+type GasFees struct {
+	// Block is the newest block the fees were sampled from
+	Block uint64
+	// BaseFee is the base fee of the pending block
+	BaseFee *big.Int
+	// SlowTip, StandardTip and FastTip are the median of the 10th, 50th and 90th percentile
+	// priority fees paid in recent blocks
+	SlowTip     *big.Int
+	StandardTip *big.Int
+	FastTip     *big.Int
+}
+
+// Slow returns the gas price of a transaction unlikely to be included soon
+func (f *GasFees) Slow() *big.Int { return new(big.Int).Add(f.BaseFee, f.SlowTip) }
+
+// Standard returns the gas price of a transaction likely to be included in the next few blocks
+func (f *GasFees) Standard() *big.Int { return new(big.Int).Add(f.BaseFee, f.StandardTip) }
+
+// Fast returns the gas price of a transaction likely to be included in the pending block
+func (f *GasFees) Fast() *big.Int { return new(big.Int).Add(f.BaseFee, f.FastTip) }
+
+// feeHistory is the result of eth_feeHistory
+type feeHistory struct {
+	OldestBlock  hexutil.Uint64   `json:"oldestBlock"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+	Reward       [][]*hexutil.Big `json:"reward"`
+}
+
+// GasFees returns the fees suggested for the pending block using eth_feeHistory. Backends that
+// can't make raw JSON-RPC calls, such as the simulated backend, don't have fee markets so their
+// suggested gas price is used as the base fee without tips
+func (c *Client) GasFees() (*GasFees, error) {
+	caller, ok := c.ec.(backend.Caller)
+	if !ok {
+		price, err := c.GasPrice()
+		if err != nil {
+			return nil, err
+		}
+		block, err := c.CurrentBlock()
+		if err != nil {
+			return nil, err
+		}
+		return &GasFees{Block: block, BaseFee: price, SlowTip: new(big.Int), StandardTip: new(big.Int), FastTip: new(big.Int)}, nil
+	}
+	var history feeHistory
+	if err := caller.CallContext(context.Background(), &history, "eth_feeHistory", hexutil.Uint(feeHistoryBlocks), "latest", feePercentiles); err != nil {
+		return nil, err
+	}
+	return history.fees()
+}
+
+// fees derives the fee suggestions from the history
+func (h *feeHistory) fees() (*GasFees, error) {
+	// base fees include the pending block after the sampled blocks
+	if len(h.BaseFee) < 2 {
+		return nil, errors.New("empty fee history")
+	}
+	fees := &GasFees{
+		Block:   uint64(h.OldestBlock) + uint64(len(h.BaseFee)) - 2,
+		BaseFee: h.BaseFee[len(h.BaseFee)-1].ToInt(),
+	}
+	tips := make([][]*big.Int, len(feePercentiles))
+	for i, rewards := range h.Reward {
+		// empty blocks report zero rewards which would drag the suggestions down
+		if i < len(h.GasUsedRatio) && h.GasUsedRatio[i] == 0 {
+			continue
+		}
+		for j := range tips {
+			if j < len(rewards) && rewards[j] != nil {
+				tips[j] = append(tips[j], rewards[j].ToInt())
+			}
+		}
+	}
+	fees.SlowTip, fees.StandardTip, fees.FastTip = median(tips[0]), median(tips[1]), median(tips[2])
+	return fees, nil
+}
+
+// median returns the median of the values, or zero if there are none
+func median(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return new(big.Int)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+	return new(big.Int).Set(values[len(values)/2])
+}
+
+// SwapCostUSD returns the USD cost of a typical uniswap swap at the gas price, valuing the network's
+// native currency through its wrapped native token's pair with the network's first stablecoin
+func (c *Client) SwapCostUSD(gasPrice *big.Int) (float64, error) {
+	if len(c.net.Stablecoins) == 0 {
+		return 0, fmt.Errorf("no stablecoins configured for %s", c.net.Name)
+	}
+	nativePrice, err := c.PairPrice(c.net.WrappedNative.String(), c.net.Stablecoins[0].String())
+	if err != nil {
+		return 0, err
+	}
+	cost, _ := utils.ToDecimal(utils.CalcGasCost(SwapGasLimit, gasPrice), 18).Float64()
+	return cost * nativePrice, nil
+}
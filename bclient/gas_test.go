@@ -0,0 +1,41 @@
+package bclient_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bonedaddy/unibot/backend"
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/bonedaddy/unibot/network"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapCostUSD(t *testing.T) {
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	defer h.Close()
+	// a network whose native currency is worth 0.5 USDC, which the WETH/DAI pair doesn't price
+	net := *network.Mainnet
+	net.WrappedNative = bclient.DEFI5TokenAddress
+	net.Stablecoins = []common.Address{bclient.USDCTokenAddress}
+	_, err = h.SeedPools(harness.Pool{
+		TokenA:  bclient.DEFI5TokenAddress,
+		TokenB:  bclient.USDCTokenAddress,
+		AmountA: utils.ToWei(int64(1000), 18),
+		AmountB: utils.ToWei(int64(500), 6),
+	})
+	require.NoError(t, err)
+	bc := bclient.NewNetworkClient(backend.NewSimulated(h.Backend), &net)
+
+	// 150000 gas at 100 gwei is 0.015 of the native currency
+	cost, err := bc.SwapCostUSD(big.NewInt(100e9))
+	require.NoError(t, err)
+	require.InDelta(t, 0.0075, cost, 1e-9)
+
+	net.Stablecoins = nil
+	_, err = bc.SwapCostUSD(big.NewInt(100e9))
+	require.Error(t, err)
+}
@@ -0,0 +1,198 @@
+package bclient
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bonedaddy/unibot/bindings/erc20"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PortfolioTTL is how long portfolios are cached for, so repeated lookups don't hit the node
+const PortfolioTTL = time.Minute
+
+// Holding is an account's balance of a token, or of the LP token of a uniswap pair
+type Holding struct {
+	// Asset is the token's symbol, or the symbols of the pair's tokens for LP tokens
+	Asset   string
+	Address common.Address
+	Balance float64
+	// USDValue is the value of the balance, 0 if the token can't be priced
+	USDValue float64
+	Priced   bool
+}
+
+// Portfolio is the value of an account's holdings
+type Portfolio struct {
+	Account  common.Address
+	Holdings []Holding
+	TotalUSD float64
+	// Fetched is when the balances were read
+	Fetched time.Time
+}
+
+// cachedPortfolio is a portfolio and the assets it was read for
+type cachedPortfolio struct {
+	assets    string
+	portfolio *Portfolio
+}
+
+// Portfolio returns the account's non zero balances of the tokens and of the LP tokens of the
+// pairs, valued in USD. Portfolios are cached for PortfolioTTL
+func (c *Client) Portfolio(account common.Address, tokens []common.Address, pairs []uniswap.Pair) (*Portfolio, error) {
+	assets := fmt.Sprint(tokens, pairs)
+	c.pmux.Lock()
+	cached, ok := c.portfolios[account]
+	c.pmux.Unlock()
+	if ok && cached.assets == assets && time.Since(cached.portfolio.Fetched) < PortfolioTTL {
+		return cached.portfolio, nil
+	}
+	portfolio := &Portfolio{Account: account, Fetched: time.Now()}
+	seen := make(map[common.Address]bool, len(tokens))
+	for _, token := range tokens {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		holding, err := c.tokenHolding(account, token)
+		if err != nil {
+			return nil, err
+		}
+		if holding != nil {
+			portfolio.add(*holding)
+		}
+	}
+	for _, pair := range pairs {
+		holding, err := c.lpHolding(account, pair)
+		if err != nil {
+			return nil, err
+		}
+		if holding != nil && !seen[holding.Address] {
+			seen[holding.Address] = true
+			portfolio.add(*holding)
+		}
+	}
+	sort.SliceStable(portfolio.Holdings, func(i, j int) bool {
+		return portfolio.Holdings[i].USDValue > portfolio.Holdings[j].USDValue
+	})
+	c.pmux.Lock()
+	// expired portfolios are dropped so looking up many accounts doesn't grow the cache without bound
+	for address, cached := range c.portfolios {
+		if time.Since(cached.portfolio.Fetched) >= PortfolioTTL {
+			delete(c.portfolios, address)
+		}
+	}
+	c.portfolios[account] = cachedPortfolio{assets: assets, portfolio: portfolio}
+	c.pmux.Unlock()
+	return portfolio, nil
+}
+
+func (p *Portfolio) add(holding Holding) {
+	p.Holdings = append(p.Holdings, holding)
+	p.TotalUSD += holding.USDValue
+}
+
+// tokenHolding returns the account's balance of the token, or nil if it holds none
+func (c *Client) tokenHolding(account, token common.Address) (*Holding, error) {
+	caller, err := erc20.NewErc20Caller(token, c.ec)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := caller.BalanceOf(&bind.CallOpts{}, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read balance of %s: %w", token.Hex(), err)
+	}
+	if balance.Sign() == 0 {
+		return nil, nil
+	}
+	info, err := c.TokenInfo(token)
+	if err != nil {
+		return nil, err
+	}
+	holding := &Holding{Asset: info.Symbol, Address: token}
+	holding.Balance, _ = utils.ToDecimal(balance, info.Decimals).Float64()
+	// tokens without a route to the stablecoin are listed without a value
+	if value, err := c.USDValue(token, balance); err == nil {
+		holding.USDValue, _ = utils.ToDecimal(value, 18).Float64()
+		holding.Priced = true
+	}
+	return holding, nil
+}
+
+// lpHolding returns the account's balance of the pair's LP token, valued as its share of the
+// pair's reserves, or nil if it holds none or the pair doesn't exist
+func (c *Client) lpHolding(account common.Address, pair uniswap.Pair) (*Holding, error) {
+	address, err := c.uc.PairAddress(pair.Token0, pair.Token1)
+	if errors.Is(err, uniswap.ErrPairNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	caller, err := erc20.NewErc20Caller(address, c.ec)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := caller.BalanceOf(&bind.CallOpts{}, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read balance of %s: %w", address.Hex(), err)
+	}
+	if balance.Sign() == 0 {
+		return nil, nil
+	}
+	supply, err := caller.TotalSupply(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	tkn0, err := c.TokenInfo(pair.Token0)
+	if err != nil {
+		return nil, err
+	}
+	tkn1, err := c.TokenInfo(pair.Token1)
+	if err != nil {
+		return nil, err
+	}
+	holding := &Holding{Asset: tkn0.Symbol + "/" + tkn1.Symbol + " LP", Address: address}
+	// LP tokens have 18 decimals
+	holding.Balance, _ = utils.ToDecimal(balance, 18).Float64()
+	reserves, err := c.uc.GetReserves(pair.Token0, pair.Token1)
+	if err != nil || supply.Sign() == 0 {
+		return holding, nil
+	}
+	var total float64
+	for _, side := range []struct {
+		token   common.Address
+		reserve *big.Int
+	}{{pair.Token0, reserves.Reserve0}, {pair.Token1, reserves.Reserve1}} {
+		share := new(big.Int).Div(new(big.Int).Mul(side.reserve, balance), supply)
+		value, err := c.USDValue(side.token, share)
+		if err != nil {
+			return holding, nil
+		}
+		usd, _ := utils.ToDecimal(value, 18).Float64()
+		total += usd
+	}
+	holding.USDValue, holding.Priced = total, true
+	return holding, nil
+}
+
+// Table renders the holdings as a fixed width table
+func (p *Portfolio) Table() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-20s %20s %16s\n", "asset", "balance", "value (USD)")
+	for _, holding := range p.Holdings {
+		value := "-"
+		if holding.Priced {
+			value = fmt.Sprintf("%.2f", holding.USDValue)
+		}
+		fmt.Fprintf(&b, "%-20s %20s %16s\n", holding.Asset, formatFloat(holding.Balance), value)
+	}
+	fmt.Fprintf(&b, "%-20s %20s %16.2f\n", "total", "", p.TotalUSD)
+	return b.String()
+}
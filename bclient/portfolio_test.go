@@ -0,0 +1,62 @@
+package bclient_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/bindings/erc20"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortfolio(t *testing.T) {
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	bc := h.Client()
+	defer bc.Close()
+	// ETH is worth 2000 DAI
+	pairs, err := h.SeedPools(harness.Pool{
+		TokenA: bclient.WETHTokenAddress, TokenB: bclient.DAITokenAddress,
+		AmountA: utils.ToWei(int64(100), 18), AmountB: utils.ToWei(int64(200000), 18),
+	})
+	require.NoError(t, err)
+
+	account := common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	require.NoError(t, h.Mint(bclient.WETHTokenAddress, account, utils.ToWei(int64(1), 18)))
+	require.NoError(t, h.Mint(bclient.DAITokenAddress, account, utils.ToWei(int64(100), 18)))
+	// a quarter of the pool's liquidity, worth 50000 DAI and 25 WETH
+	supply, err := h.TotalSupply(pairs[0])
+	require.NoError(t, err)
+	share := new(big.Int).Div(supply, big.NewInt(4))
+	require.NoError(t, h.Transact(pairs[0], erc20.Erc20ABI, "transfer", account, share))
+
+	tokens := []common.Address{bclient.WETHTokenAddress, bclient.DAITokenAddress, bclient.USDCTokenAddress}
+	lps := []uniswap.Pair{
+		{Token0: bclient.WETHTokenAddress, Token1: bclient.DAITokenAddress},
+		// pairs that were never created are skipped
+		{Token0: bclient.NDXTokenAddress, Token1: bclient.DAITokenAddress},
+	}
+	portfolio, err := bc.Portfolio(account, tokens, lps)
+	require.NoError(t, err)
+	// the USDC balance is zero so it isn't listed
+	require.Len(t, portfolio.Holdings, 3)
+	require.Equal(t, "WETH/DAI LP", portfolio.Holdings[0].Asset)
+	require.Equal(t, pairs[0], portfolio.Holdings[0].Address)
+	require.InEpsilon(t, 100000, portfolio.Holdings[0].USDValue, 0.02)
+	require.Equal(t, "WETH", portfolio.Holdings[1].Asset)
+	require.Equal(t, float64(1), portfolio.Holdings[1].Balance)
+	require.InEpsilon(t, 2000, portfolio.Holdings[1].USDValue, 0.02)
+	require.Equal(t, "DAI", portfolio.Holdings[2].Asset)
+	require.InEpsilon(t, 100, portfolio.Holdings[2].USDValue, 0.0001)
+	require.InEpsilon(t, 102100, portfolio.TotalUSD, 0.02)
+	require.Contains(t, portfolio.Table(), "WETH")
+
+	// portfolios are cached
+	cached, err := bc.Portfolio(account, tokens, lps)
+	require.NoError(t, err)
+	require.Same(t, portfolio, cached)
+}
@@ -0,0 +1,105 @@
+package bclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Call is an unsigned contract call, which may be simulated or signed and sent as a transaction
+type Call struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// LoadKeystoreKey decrypts the private key stored in the keystore file at path
+func LoadKeystoreKey(path, passphrase string) (*ecdsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey, nil
+}
+
+// LoadHexKey reads a hex encoded private key from the file at path
+func LoadHexKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+}
+
+// Simulate executes the call against the latest block via eth_call without creating a transaction
+func (c *Client) Simulate(call Call) ([]byte, error) {
+	return c.ec.CallContract(context.Background(), ethereum.CallMsg{
+		From:  call.From,
+		To:    &call.To,
+		Value: call.Value,
+		Data:  call.Data,
+	}, nil)
+}
+
+// Send signs the call with key as a transaction and broadcasts it, using the pending nonce,
+// the suggested gas price and an estimated gas limit
+func (c *Client) Send(key *ecdsa.PrivateKey, call Call) (*types.Transaction, error) {
+	ctx := context.Background()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	if call.From != (common.Address{}) && call.From != from {
+		return nil, errors.New("call sender does not match signing key")
+	}
+	value := call.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	nonce, err := c.ec.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := c.ec.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := c.ec.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &call.To, Value: value, Data: call.Data})
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := c.chainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := types.SignTx(
+		types.NewTransaction(nonce, call.To, value, gasLimit, gasPrice, call.Data),
+		types.NewEIP155Signer(chainID),
+		key,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tx, c.ec.SendTransaction(ctx, tx)
+}
+
+func (c *Client) chainID(ctx context.Context) (*big.Int, error) {
+	ec, ok := c.ec.(interface {
+		ChainID(context.Context) (*big.Int, error)
+	})
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	return ec.ChainID(ctx)
+}
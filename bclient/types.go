@@ -1,6 +1,7 @@
 package bclient
 
 import (
+	"github.com/bonedaddy/unibot/network"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -16,13 +17,22 @@ var (
 	WETHTokenAddress = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
 	// DAITokenAddress is the address of the MCD (Multi Collateral DAI) contract
 	DAITokenAddress = common.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	// USDCTokenAddress is the address of the USDC contract
+	USDCTokenAddress = common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	// USDTTokenAddress is the address of the USDT contract
+	USDTTokenAddress = common.HexToAddress("0xdac17f958d2ee523a2206206994597c13d831ec7")
 	// NDXTokenAddress is the address of the NDX contract
 	NDXTokenAddress = common.HexToAddress("0x86772b1409b61c639eaac9ba0acfbb6e238e5f83")
 
+	// oracles
+
+	// ChainlinkETHUSDFeed is the address of the chainlink ETH/USD aggregator
+	ChainlinkETHUSDFeed = common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419")
+
 	// misc variables
 
-	// InfuraWSURL is the URL for INFURA websockets access
-	InfuraWSURL = "wss://mainnet.infura.io/ws/v3/"
-	// InfuraHTTPURL is the URL for INFURA HTTP access
-	InfuraHTTPURL = "https://mainnet.infura.io/v3/"
+	// InfuraWSURL is the URL for INFURA mainnet websockets access
+	InfuraWSURL = network.Mainnet.InfuraWSURL
+	// InfuraHTTPURL is the URL for INFURA mainnet HTTP access
+	InfuraHTTPURL = network.Mainnet.InfuraHTTPURL
 )
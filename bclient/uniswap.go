@@ -1,9 +1,14 @@
 package bclient
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"strings"
 
 	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -34,3 +39,191 @@ func (c *Client) Reserves(token0, token1 string) (*uniswap.Reserve, error) {
 func (c *Client) ExchangeAmount(amount *big.Int, token0, token1 string) (*big.Int, error) {
 	return c.uc.GetExchangeAmount(amount, common.HexToAddress(token0), common.HexToAddress(token1))
 }
+
+// USDValue returns the value of the given amount of token denominated in the network's first
+// stablecoin, which is DAI on mainnet, scaled to 18 decimals. Other tokens are routed through
+// the wrapped native token
+func (c *Client) USDValue(token common.Address, amount *big.Int) (*big.Int, error) {
+	if len(c.net.Stablecoins) == 0 {
+		return nil, fmt.Errorf("no stablecoins configured for %s", c.net.Name)
+	}
+	stable, wrapped := c.net.Stablecoins[0], c.net.WrappedNative
+	var (
+		value *big.Int
+		err   error
+	)
+	switch token {
+	case stable:
+		value = new(big.Int).Set(amount)
+	case wrapped:
+		value, err = c.uc.GetExchangeAmount(amount, wrapped, stable)
+	default:
+		value, err = c.uc.GetExchangeAmountForPath(amount, token, wrapped, stable)
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.TokenInfo(stable)
+	if err != nil {
+		return nil, err
+	}
+	if info.Decimals < 18 {
+		value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(18-info.Decimals)), nil))
+	}
+	return value, nil
+}
+
+// PairPrice returns the price of one whole token0 denominated in token1, adjusted for the decimals of both tokens
+func (c *Client) PairPrice(token0, token1 string) (float64, error) {
+	tkn0, err := c.TokenInfo(common.HexToAddress(token0))
+	if err != nil {
+		return 0, err
+	}
+	tkn1, err := c.TokenInfo(common.HexToAddress(token1))
+	if err != nil {
+		return 0, err
+	}
+	amount, err := c.ExchangeAmount(utils.ToWei(int64(1), tkn0.Decimals), token0, token1)
+	if err != nil {
+		return 0, err
+	}
+	price, _ := utils.ToDecimal(amount, tkn1.Decimals).Float64()
+	return price, nil
+}
+
+// SpotPrice returns the mid price of one whole token0 denominated in token1 from the ratio of the
+// pair's reserves, adjusted for the decimals of both tokens. Unlike PairPrice it excludes the fee and
+// the price impact of trading a whole token
+func (c *Client) SpotPrice(token0, token1 string) (float64, error) {
+	tkn0, err := c.TokenInfo(common.HexToAddress(token0))
+	if err != nil {
+		return 0, err
+	}
+	tkn1, err := c.TokenInfo(common.HexToAddress(token1))
+	if err != nil {
+		return 0, err
+	}
+	reserves, err := c.Reserves(token0, token1)
+	if err != nil {
+		return 0, err
+	}
+	if reserves.Reserve0.Sign() <= 0 {
+		return 0, fmt.Errorf("no %s liquidity in the %s/%s pair", tkn0.Symbol, tkn0.Symbol, tkn1.Symbol)
+	}
+	// reserve1 * 10^decimals0 / (reserve0 * 10^decimals1), kept exact until the final division
+	scale := func(reserve *big.Int, decimals int) *big.Float {
+		return new(big.Float).SetInt(new(big.Int).Mul(reserve, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	}
+	price, _ := new(big.Float).Quo(scale(reserves.Reserve1, tkn0.Decimals), scale(reserves.Reserve0, tkn1.Decimals)).Float64()
+	return price, nil
+}
+
+// V3PairPrice returns the price of one whole token0 denominated in token1 in the v3 pool with the given fee tier
+func (c *Client) V3PairPrice(token0, token1 string, fee uint32) (float64, error) {
+	tkn0, err := c.TokenInfo(common.HexToAddress(token0))
+	if err != nil {
+		return 0, err
+	}
+	tkn1, err := c.TokenInfo(common.HexToAddress(token1))
+	if err != nil {
+		return 0, err
+	}
+	pool, err := c.uc.GetV3Pool(tkn0.Address, tkn1.Address, fee, 0)
+	if err != nil {
+		return 0, err
+	}
+	// the pool price is token0 in terms of token1 in sorted order
+	if pool.Token0 == tkn0.Address {
+		return pool.Price(tkn0.Decimals, tkn1.Decimals), nil
+	}
+	price := pool.Price(tkn1.Decimals, tkn0.Decimals)
+	if price == 0 {
+		return 0, errors.New("pool has no price")
+	}
+	return 1 / price, nil
+}
+
+// LiquidityDepth describes the liquidity of a pair as the trades needed to move the price of token0
+// by each of the default depth moves, and a synthetic order book ladder with decimal adjusted prices
+type LiquidityDepth struct {
+	Token0 *Token
+	Token1 *Token
+	Price  float64
+	Levels []uniswap.DepthLevel
+	Bids   []uniswap.OrderBookLevel
+	Asks   []uniswap.OrderBookLevel
+}
+
+// Depth returns the liquidity depth of the token0/token1 pair, with an order book ladder of the
+// given number of levels spaced step apart
+func (c *Client) Depth(token0, token1 string, step float64, levels int) (*LiquidityDepth, error) {
+	tkn0, err := c.TokenInfo(common.HexToAddress(token0))
+	if err != nil {
+		return nil, err
+	}
+	tkn1, err := c.TokenInfo(common.HexToAddress(token1))
+	if err != nil {
+		return nil, err
+	}
+	reserves, err := c.uc.GetReserves(tkn0.Address, tkn1.Address)
+	if err != nil {
+		return nil, err
+	}
+	// raw prices are converted to whole token prices by scaling with 10^(decimals0 - decimals1)
+	scale := math.Pow10(tkn0.Decimals - tkn1.Decimals)
+	bids, asks := uniswap.OrderBook(reserves.Reserve0, reserves.Reserve1, step, levels)
+	for i := range bids {
+		bids[i].Price *= scale
+	}
+	for i := range asks {
+		asks[i].Price *= scale
+	}
+	price, _ := new(big.Rat).SetFrac(reserves.Reserve1, reserves.Reserve0).Float64()
+	return &LiquidityDepth{
+		Token0: tkn0,
+		Token1: tkn1,
+		Price:  price * scale,
+		Levels: uniswap.Depth(reserves.Reserve0, reserves.Reserve1, uniswap.DefaultDepthMoves),
+		Bids:   bids,
+		Asks:   asks,
+	}, nil
+}
+
+// Table renders the depth levels followed by the order book ladder as a fixed width text table
+func (d *LiquidityDepth) Table() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s/%s price: %s\n\n", d.Token0.Symbol, d.Token1.Symbol, formatFloat(d.Price))
+	fmt.Fprintf(&b, "%-8s %20s %20s\n", "move", "pay", "receive")
+	for _, level := range d.Levels {
+		in, out := d.Token1, d.Token0
+		if level.Move < 0 {
+			in, out = d.Token0, d.Token1
+		}
+		fmt.Fprintf(
+			&b, "%-8s %20s %20s\n",
+			fmt.Sprintf("%+.0f%%", level.Move*100),
+			utils.ToDecimal(level.AmountIn, in.Decimals).StringFixed(4)+" "+in.Symbol,
+			utils.ToDecimal(level.AmountOut, out.Decimals).StringFixed(4)+" "+out.Symbol,
+		)
+	}
+	if len(d.Asks) == 0 && len(d.Bids) == 0 {
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\n%-6s %16s %20s\n", "side", "price", d.Token0.Symbol)
+	// asks are listed from the highest price down so the book reads top to bottom
+	for i := len(d.Asks) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "%-6s %16s %20s\n", "ask", formatFloat(d.Asks[i].Price), utils.ToDecimal(d.Asks[i].Amount, d.Token0.Decimals).StringFixed(4))
+	}
+	for _, bid := range d.Bids {
+		fmt.Fprintf(&b, "%-6s %16s %20s\n", "bid", formatFloat(bid.Price), utils.ToDecimal(bid.Amount, d.Token0.Decimals).StringFixed(4))
+	}
+	return b.String()
+}
+
+// formatFloat formats prices with enough precision for tokens worth fractions of a cent
+func formatFloat(v float64) string {
+	if v != 0 && math.Abs(v) < 0.01 {
+		return fmt.Sprintf("%.8f", v)
+	}
+	return fmt.Sprintf("%.4f", v)
+}
@@ -0,0 +1,379 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package chainlinkaggregator
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ChainlinkaggregatorABI is the input ABI used to generate the binding from.
+const ChainlinkaggregatorABI = "[{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"description\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint80\",\"name\":\"_roundId\",\"type\":\"uint80\"}],\"name\":\"getRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"latestRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"version\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Chainlinkaggregator is an auto generated Go binding around an Ethereum contract.
+type Chainlinkaggregator struct {
+	ChainlinkaggregatorCaller     // Read-only binding to the contract
+	ChainlinkaggregatorTransactor // Write-only binding to the contract
+	ChainlinkaggregatorFilterer   // Log filterer for contract events
+}
+
+// ChainlinkaggregatorCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ChainlinkaggregatorCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ChainlinkaggregatorTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ChainlinkaggregatorTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ChainlinkaggregatorFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ChainlinkaggregatorFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ChainlinkaggregatorSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ChainlinkaggregatorSession struct {
+	Contract     *Chainlinkaggregator // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts        // Call options to use throughout this session
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// ChainlinkaggregatorCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ChainlinkaggregatorCallerSession struct {
+	Contract *ChainlinkaggregatorCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts              // Call options to use throughout this session
+}
+
+// ChainlinkaggregatorTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ChainlinkaggregatorTransactorSession struct {
+	Contract     *ChainlinkaggregatorTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts              // Transaction auth options to use throughout this session
+}
+
+// ChainlinkaggregatorRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ChainlinkaggregatorRaw struct {
+	Contract *Chainlinkaggregator // Generic contract binding to access the raw methods on
+}
+
+// ChainlinkaggregatorCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ChainlinkaggregatorCallerRaw struct {
+	Contract *ChainlinkaggregatorCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ChainlinkaggregatorTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ChainlinkaggregatorTransactorRaw struct {
+	Contract *ChainlinkaggregatorTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewChainlinkaggregator creates a new instance of Chainlinkaggregator, bound to a specific deployed contract.
+func NewChainlinkaggregator(address common.Address, backend bind.ContractBackend) (*Chainlinkaggregator, error) {
+	contract, err := bindChainlinkaggregator(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Chainlinkaggregator{ChainlinkaggregatorCaller: ChainlinkaggregatorCaller{contract: contract}, ChainlinkaggregatorTransactor: ChainlinkaggregatorTransactor{contract: contract}, ChainlinkaggregatorFilterer: ChainlinkaggregatorFilterer{contract: contract}}, nil
+}
+
+// NewChainlinkaggregatorCaller creates a new read-only instance of Chainlinkaggregator, bound to a specific deployed contract.
+func NewChainlinkaggregatorCaller(address common.Address, caller bind.ContractCaller) (*ChainlinkaggregatorCaller, error) {
+	contract, err := bindChainlinkaggregator(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ChainlinkaggregatorCaller{contract: contract}, nil
+}
+
+// NewChainlinkaggregatorTransactor creates a new write-only instance of Chainlinkaggregator, bound to a specific deployed contract.
+func NewChainlinkaggregatorTransactor(address common.Address, transactor bind.ContractTransactor) (*ChainlinkaggregatorTransactor, error) {
+	contract, err := bindChainlinkaggregator(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ChainlinkaggregatorTransactor{contract: contract}, nil
+}
+
+// NewChainlinkaggregatorFilterer creates a new log filterer instance of Chainlinkaggregator, bound to a specific deployed contract.
+func NewChainlinkaggregatorFilterer(address common.Address, filterer bind.ContractFilterer) (*ChainlinkaggregatorFilterer, error) {
+	contract, err := bindChainlinkaggregator(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ChainlinkaggregatorFilterer{contract: contract}, nil
+}
+
+// bindChainlinkaggregator binds a generic wrapper to an already deployed contract.
+func bindChainlinkaggregator(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ChainlinkaggregatorABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Chainlinkaggregator *ChainlinkaggregatorRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Chainlinkaggregator.Contract.ChainlinkaggregatorCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Chainlinkaggregator *ChainlinkaggregatorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Chainlinkaggregator.Contract.ChainlinkaggregatorTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Chainlinkaggregator *ChainlinkaggregatorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Chainlinkaggregator.Contract.ChainlinkaggregatorTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Chainlinkaggregator *ChainlinkaggregatorCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Chainlinkaggregator.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Chainlinkaggregator *ChainlinkaggregatorTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Chainlinkaggregator.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Chainlinkaggregator *ChainlinkaggregatorTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Chainlinkaggregator.Contract.contract.Transact(opts, method, params...)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_Chainlinkaggregator *ChainlinkaggregatorCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _Chainlinkaggregator.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_Chainlinkaggregator *ChainlinkaggregatorSession) Decimals() (uint8, error) {
+	return _Chainlinkaggregator.Contract.Decimals(&_Chainlinkaggregator.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_Chainlinkaggregator *ChainlinkaggregatorCallerSession) Decimals() (uint8, error) {
+	return _Chainlinkaggregator.Contract.Decimals(&_Chainlinkaggregator.CallOpts)
+}
+
+// Description is a free data retrieval call binding the contract method 0x7284e416.
+//
+// Solidity: function description() view returns(string)
+func (_Chainlinkaggregator *ChainlinkaggregatorCaller) Description(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Chainlinkaggregator.contract.Call(opts, &out, "description")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Description is a free data retrieval call binding the contract method 0x7284e416.
+//
+// Solidity: function description() view returns(string)
+func (_Chainlinkaggregator *ChainlinkaggregatorSession) Description() (string, error) {
+	return _Chainlinkaggregator.Contract.Description(&_Chainlinkaggregator.CallOpts)
+}
+
+// Description is a free data retrieval call binding the contract method 0x7284e416.
+//
+// Solidity: function description() view returns(string)
+func (_Chainlinkaggregator *ChainlinkaggregatorCallerSession) Description() (string, error) {
+	return _Chainlinkaggregator.Contract.Description(&_Chainlinkaggregator.CallOpts)
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0x9a6fc8f5.
+//
+// Solidity: function getRoundData(uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_Chainlinkaggregator *ChainlinkaggregatorCaller) GetRoundData(opts *bind.CallOpts, _roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	var out []interface{}
+	err := _Chainlinkaggregator.contract.Call(opts, &out, "getRoundData", _roundId)
+
+	outstruct := new(struct {
+		RoundId         *big.Int
+		Answer          *big.Int
+		StartedAt       *big.Int
+		UpdatedAt       *big.Int
+		AnsweredInRound *big.Int
+	})
+
+	outstruct.RoundId = out[0].(*big.Int)
+	outstruct.Answer = out[1].(*big.Int)
+	outstruct.StartedAt = out[2].(*big.Int)
+	outstruct.UpdatedAt = out[3].(*big.Int)
+	outstruct.AnsweredInRound = out[4].(*big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0x9a6fc8f5.
+//
+// Solidity: function getRoundData(uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_Chainlinkaggregator *ChainlinkaggregatorSession) GetRoundData(_roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _Chainlinkaggregator.Contract.GetRoundData(&_Chainlinkaggregator.CallOpts, _roundId)
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0x9a6fc8f5.
+//
+// Solidity: function getRoundData(uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_Chainlinkaggregator *ChainlinkaggregatorCallerSession) GetRoundData(_roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _Chainlinkaggregator.Contract.GetRoundData(&_Chainlinkaggregator.CallOpts, _roundId)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xfeaf968c.
+//
+// Solidity: function latestRoundData() view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_Chainlinkaggregator *ChainlinkaggregatorCaller) LatestRoundData(opts *bind.CallOpts) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	var out []interface{}
+	err := _Chainlinkaggregator.contract.Call(opts, &out, "latestRoundData")
+
+	outstruct := new(struct {
+		RoundId         *big.Int
+		Answer          *big.Int
+		StartedAt       *big.Int
+		UpdatedAt       *big.Int
+		AnsweredInRound *big.Int
+	})
+
+	outstruct.RoundId = out[0].(*big.Int)
+	outstruct.Answer = out[1].(*big.Int)
+	outstruct.StartedAt = out[2].(*big.Int)
+	outstruct.UpdatedAt = out[3].(*big.Int)
+	outstruct.AnsweredInRound = out[4].(*big.Int)
+
+	return *outstruct, err
+
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xfeaf968c.
+//
+// Solidity: function latestRoundData() view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_Chainlinkaggregator *ChainlinkaggregatorSession) LatestRoundData() (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _Chainlinkaggregator.Contract.LatestRoundData(&_Chainlinkaggregator.CallOpts)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xfeaf968c.
+//
+// Solidity: function latestRoundData() view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_Chainlinkaggregator *ChainlinkaggregatorCallerSession) LatestRoundData() (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _Chainlinkaggregator.Contract.LatestRoundData(&_Chainlinkaggregator.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint256)
+func (_Chainlinkaggregator *ChainlinkaggregatorCaller) Version(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Chainlinkaggregator.contract.Call(opts, &out, "version")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint256)
+func (_Chainlinkaggregator *ChainlinkaggregatorSession) Version() (*big.Int, error) {
+	return _Chainlinkaggregator.Contract.Version(&_Chainlinkaggregator.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint256)
+func (_Chainlinkaggregator *ChainlinkaggregatorCallerSession) Version() (*big.Int, error) {
+	return _Chainlinkaggregator.Contract.Version(&_Chainlinkaggregator.CallOpts)
+}
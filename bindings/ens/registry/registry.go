@@ -0,0 +1,234 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ensregistry
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// EnsregistryABI is the input ABI used to generate the binding from.
+const EnsregistryABI = "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"resolver\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Ensregistry is an auto generated Go binding around an Ethereum contract.
+type Ensregistry struct {
+	EnsregistryCaller     // Read-only binding to the contract
+	EnsregistryTransactor // Write-only binding to the contract
+	EnsregistryFilterer   // Log filterer for contract events
+}
+
+// EnsregistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type EnsregistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EnsregistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type EnsregistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EnsregistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type EnsregistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EnsregistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type EnsregistrySession struct {
+	Contract     *Ensregistry      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// EnsregistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type EnsregistryCallerSession struct {
+	Contract *EnsregistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// EnsregistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type EnsregistryTransactorSession struct {
+	Contract     *EnsregistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// EnsregistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type EnsregistryRaw struct {
+	Contract *Ensregistry // Generic contract binding to access the raw methods on
+}
+
+// EnsregistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type EnsregistryCallerRaw struct {
+	Contract *EnsregistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// EnsregistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type EnsregistryTransactorRaw struct {
+	Contract *EnsregistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewEnsregistry creates a new instance of Ensregistry, bound to a specific deployed contract.
+func NewEnsregistry(address common.Address, backend bind.ContractBackend) (*Ensregistry, error) {
+	contract, err := bindEnsregistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Ensregistry{EnsregistryCaller: EnsregistryCaller{contract: contract}, EnsregistryTransactor: EnsregistryTransactor{contract: contract}, EnsregistryFilterer: EnsregistryFilterer{contract: contract}}, nil
+}
+
+// NewEnsregistryCaller creates a new read-only instance of Ensregistry, bound to a specific deployed contract.
+func NewEnsregistryCaller(address common.Address, caller bind.ContractCaller) (*EnsregistryCaller, error) {
+	contract, err := bindEnsregistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EnsregistryCaller{contract: contract}, nil
+}
+
+// NewEnsregistryTransactor creates a new write-only instance of Ensregistry, bound to a specific deployed contract.
+func NewEnsregistryTransactor(address common.Address, transactor bind.ContractTransactor) (*EnsregistryTransactor, error) {
+	contract, err := bindEnsregistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EnsregistryTransactor{contract: contract}, nil
+}
+
+// NewEnsregistryFilterer creates a new log filterer instance of Ensregistry, bound to a specific deployed contract.
+func NewEnsregistryFilterer(address common.Address, filterer bind.ContractFilterer) (*EnsregistryFilterer, error) {
+	contract, err := bindEnsregistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &EnsregistryFilterer{contract: contract}, nil
+}
+
+// bindEnsregistry binds a generic wrapper to an already deployed contract.
+func bindEnsregistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(EnsregistryABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Ensregistry *EnsregistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Ensregistry.Contract.EnsregistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Ensregistry *EnsregistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Ensregistry.Contract.EnsregistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Ensregistry *EnsregistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Ensregistry.Contract.EnsregistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Ensregistry *EnsregistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Ensregistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Ensregistry *EnsregistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Ensregistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Ensregistry *EnsregistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Ensregistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 node) view returns(address)
+func (_Ensregistry *EnsregistryCaller) Owner(opts *bind.CallOpts, node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _Ensregistry.contract.Call(opts, &out, "owner", node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 node) view returns(address)
+func (_Ensregistry *EnsregistrySession) Owner(node [32]byte) (common.Address, error) {
+	return _Ensregistry.Contract.Owner(&_Ensregistry.CallOpts, node)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 node) view returns(address)
+func (_Ensregistry *EnsregistryCallerSession) Owner(node [32]byte) (common.Address, error) {
+	return _Ensregistry.Contract.Owner(&_Ensregistry.CallOpts, node)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 node) view returns(address)
+func (_Ensregistry *EnsregistryCaller) Resolver(opts *bind.CallOpts, node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _Ensregistry.contract.Call(opts, &out, "resolver", node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 node) view returns(address)
+func (_Ensregistry *EnsregistrySession) Resolver(node [32]byte) (common.Address, error) {
+	return _Ensregistry.Contract.Resolver(&_Ensregistry.CallOpts, node)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 node) view returns(address)
+func (_Ensregistry *EnsregistryCallerSession) Resolver(node [32]byte) (common.Address, error) {
+	return _Ensregistry.Contract.Resolver(&_Ensregistry.CallOpts, node)
+}
@@ -0,0 +1,234 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ensresolver
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// EnsresolverABI is the input ABI used to generate the binding from.
+const EnsresolverABI = "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"addr\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Ensresolver is an auto generated Go binding around an Ethereum contract.
+type Ensresolver struct {
+	EnsresolverCaller     // Read-only binding to the contract
+	EnsresolverTransactor // Write-only binding to the contract
+	EnsresolverFilterer   // Log filterer for contract events
+}
+
+// EnsresolverCaller is an auto generated read-only Go binding around an Ethereum contract.
+type EnsresolverCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EnsresolverTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type EnsresolverTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EnsresolverFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type EnsresolverFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EnsresolverSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type EnsresolverSession struct {
+	Contract     *Ensresolver      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// EnsresolverCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type EnsresolverCallerSession struct {
+	Contract *EnsresolverCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// EnsresolverTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type EnsresolverTransactorSession struct {
+	Contract     *EnsresolverTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// EnsresolverRaw is an auto generated low-level Go binding around an Ethereum contract.
+type EnsresolverRaw struct {
+	Contract *Ensresolver // Generic contract binding to access the raw methods on
+}
+
+// EnsresolverCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type EnsresolverCallerRaw struct {
+	Contract *EnsresolverCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// EnsresolverTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type EnsresolverTransactorRaw struct {
+	Contract *EnsresolverTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewEnsresolver creates a new instance of Ensresolver, bound to a specific deployed contract.
+func NewEnsresolver(address common.Address, backend bind.ContractBackend) (*Ensresolver, error) {
+	contract, err := bindEnsresolver(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Ensresolver{EnsresolverCaller: EnsresolverCaller{contract: contract}, EnsresolverTransactor: EnsresolverTransactor{contract: contract}, EnsresolverFilterer: EnsresolverFilterer{contract: contract}}, nil
+}
+
+// NewEnsresolverCaller creates a new read-only instance of Ensresolver, bound to a specific deployed contract.
+func NewEnsresolverCaller(address common.Address, caller bind.ContractCaller) (*EnsresolverCaller, error) {
+	contract, err := bindEnsresolver(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EnsresolverCaller{contract: contract}, nil
+}
+
+// NewEnsresolverTransactor creates a new write-only instance of Ensresolver, bound to a specific deployed contract.
+func NewEnsresolverTransactor(address common.Address, transactor bind.ContractTransactor) (*EnsresolverTransactor, error) {
+	contract, err := bindEnsresolver(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EnsresolverTransactor{contract: contract}, nil
+}
+
+// NewEnsresolverFilterer creates a new log filterer instance of Ensresolver, bound to a specific deployed contract.
+func NewEnsresolverFilterer(address common.Address, filterer bind.ContractFilterer) (*EnsresolverFilterer, error) {
+	contract, err := bindEnsresolver(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &EnsresolverFilterer{contract: contract}, nil
+}
+
+// bindEnsresolver binds a generic wrapper to an already deployed contract.
+func bindEnsresolver(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(EnsresolverABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Ensresolver *EnsresolverRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Ensresolver.Contract.EnsresolverCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Ensresolver *EnsresolverRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Ensresolver.Contract.EnsresolverTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Ensresolver *EnsresolverRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Ensresolver.Contract.EnsresolverTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Ensresolver *EnsresolverCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Ensresolver.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Ensresolver *EnsresolverTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Ensresolver.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Ensresolver *EnsresolverTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Ensresolver.Contract.contract.Transact(opts, method, params...)
+}
+
+// Addr is a free data retrieval call binding the contract method 0x3b3b57de.
+//
+// Solidity: function addr(bytes32 node) view returns(address)
+func (_Ensresolver *EnsresolverCaller) Addr(opts *bind.CallOpts, node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _Ensresolver.contract.Call(opts, &out, "addr", node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Addr is a free data retrieval call binding the contract method 0x3b3b57de.
+//
+// Solidity: function addr(bytes32 node) view returns(address)
+func (_Ensresolver *EnsresolverSession) Addr(node [32]byte) (common.Address, error) {
+	return _Ensresolver.Contract.Addr(&_Ensresolver.CallOpts, node)
+}
+
+// Addr is a free data retrieval call binding the contract method 0x3b3b57de.
+//
+// Solidity: function addr(bytes32 node) view returns(address)
+func (_Ensresolver *EnsresolverCallerSession) Addr(node [32]byte) (common.Address, error) {
+	return _Ensresolver.Contract.Addr(&_Ensresolver.CallOpts, node)
+}
+
+// Name is a free data retrieval call binding the contract method 0x691f3431.
+//
+// Solidity: function name(bytes32 node) view returns(string)
+func (_Ensresolver *EnsresolverCaller) Name(opts *bind.CallOpts, node [32]byte) (string, error) {
+	var out []interface{}
+	err := _Ensresolver.contract.Call(opts, &out, "name", node)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x691f3431.
+//
+// Solidity: function name(bytes32 node) view returns(string)
+func (_Ensresolver *EnsresolverSession) Name(node [32]byte) (string, error) {
+	return _Ensresolver.Contract.Name(&_Ensresolver.CallOpts, node)
+}
+
+// Name is a free data retrieval call binding the contract method 0x691f3431.
+//
+// Solidity: function name(bytes32 node) view returns(string)
+func (_Ensresolver *EnsresolverCallerSession) Name(node [32]byte) (string, error) {
+	return _Ensresolver.Contract.Name(&_Ensresolver.CallOpts, node)
+}
@@ -0,0 +1,686 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package uniswapv3pool
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// Uniswapv3poolABI is the input ABI used to generate the binding from.
+const Uniswapv3poolABI = "[{\"inputs\":[],\"name\":\"factory\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"fee\",\"outputs\":[{\"internalType\":\"uint24\",\"name\":\"\",\"type\":\"uint24\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"liquidity\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"\",\"type\":\"uint128\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"slot0\",\"outputs\":[{\"internalType\":\"uint160\",\"name\":\"sqrtPriceX96\",\"type\":\"uint160\"},{\"internalType\":\"int24\",\"name\":\"tick\",\"type\":\"int24\"},{\"internalType\":\"uint16\",\"name\":\"observationIndex\",\"type\":\"uint16\"},{\"internalType\":\"uint16\",\"name\":\"observationCardinality\",\"type\":\"uint16\"},{\"internalType\":\"uint16\",\"name\":\"observationCardinalityNext\",\"type\":\"uint16\"},{\"internalType\":\"uint8\",\"name\":\"feeProtocol\",\"type\":\"uint8\"},{\"internalType\":\"bool\",\"name\":\"unlocked\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"int16\",\"name\":\"\",\"type\":\"int16\"}],\"name\":\"tickBitmap\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tickSpacing\",\"outputs\":[{\"internalType\":\"int24\",\"name\":\"\",\"type\":\"int24\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"int24\",\"name\":\"\",\"type\":\"int24\"}],\"name\":\"ticks\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"liquidityGross\",\"type\":\"uint128\"},{\"internalType\":\"int128\",\"name\":\"liquidityNet\",\"type\":\"int128\"},{\"internalType\":\"uint256\",\"name\":\"feeGrowthOutside0X128\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"feeGrowthOutside1X128\",\"type\":\"uint256\"},{\"internalType\":\"int56\",\"name\":\"tickCumulativeOutside\",\"type\":\"int56\"},{\"internalType\":\"uint160\",\"name\":\"secondsPerLiquidityOutsideX128\",\"type\":\"uint160\"},{\"internalType\":\"uint32\",\"name\":\"secondsOutside\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"initialized\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"token0\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"token1\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"int256\",\"name\":\"amount0\",\"type\":\"int256\"},{\"indexed\":false,\"internalType\":\"int256\",\"name\":\"amount1\",\"type\":\"int256\"},{\"indexed\":false,\"internalType\":\"uint160\",\"name\":\"sqrtPriceX96\",\"type\":\"uint160\"},{\"indexed\":false,\"internalType\":\"uint128\",\"name\":\"liquidity\",\"type\":\"uint128\"},{\"indexed\":false,\"internalType\":\"int24\",\"name\":\"tick\",\"type\":\"int24\"}],\"name\":\"Swap\",\"type\":\"event\"}]"
+
+// Uniswapv3pool is an auto generated Go binding around an Ethereum contract.
+type Uniswapv3pool struct {
+	Uniswapv3poolCaller     // Read-only binding to the contract
+	Uniswapv3poolTransactor // Write-only binding to the contract
+	Uniswapv3poolFilterer   // Log filterer for contract events
+}
+
+// Uniswapv3poolCaller is an auto generated read-only Go binding around an Ethereum contract.
+type Uniswapv3poolCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// Uniswapv3poolTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type Uniswapv3poolTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// Uniswapv3poolFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type Uniswapv3poolFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// Uniswapv3poolSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type Uniswapv3poolSession struct {
+	Contract     *Uniswapv3pool    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// Uniswapv3poolCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type Uniswapv3poolCallerSession struct {
+	Contract *Uniswapv3poolCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// Uniswapv3poolTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type Uniswapv3poolTransactorSession struct {
+	Contract     *Uniswapv3poolTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// Uniswapv3poolRaw is an auto generated low-level Go binding around an Ethereum contract.
+type Uniswapv3poolRaw struct {
+	Contract *Uniswapv3pool // Generic contract binding to access the raw methods on
+}
+
+// Uniswapv3poolCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type Uniswapv3poolCallerRaw struct {
+	Contract *Uniswapv3poolCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// Uniswapv3poolTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type Uniswapv3poolTransactorRaw struct {
+	Contract *Uniswapv3poolTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewUniswapv3pool creates a new instance of Uniswapv3pool, bound to a specific deployed contract.
+func NewUniswapv3pool(address common.Address, backend bind.ContractBackend) (*Uniswapv3pool, error) {
+	contract, err := bindUniswapv3pool(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Uniswapv3pool{Uniswapv3poolCaller: Uniswapv3poolCaller{contract: contract}, Uniswapv3poolTransactor: Uniswapv3poolTransactor{contract: contract}, Uniswapv3poolFilterer: Uniswapv3poolFilterer{contract: contract}}, nil
+}
+
+// NewUniswapv3poolCaller creates a new read-only instance of Uniswapv3pool, bound to a specific deployed contract.
+func NewUniswapv3poolCaller(address common.Address, caller bind.ContractCaller) (*Uniswapv3poolCaller, error) {
+	contract, err := bindUniswapv3pool(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Uniswapv3poolCaller{contract: contract}, nil
+}
+
+// NewUniswapv3poolTransactor creates a new write-only instance of Uniswapv3pool, bound to a specific deployed contract.
+func NewUniswapv3poolTransactor(address common.Address, transactor bind.ContractTransactor) (*Uniswapv3poolTransactor, error) {
+	contract, err := bindUniswapv3pool(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Uniswapv3poolTransactor{contract: contract}, nil
+}
+
+// NewUniswapv3poolFilterer creates a new log filterer instance of Uniswapv3pool, bound to a specific deployed contract.
+func NewUniswapv3poolFilterer(address common.Address, filterer bind.ContractFilterer) (*Uniswapv3poolFilterer, error) {
+	contract, err := bindUniswapv3pool(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &Uniswapv3poolFilterer{contract: contract}, nil
+}
+
+// bindUniswapv3pool binds a generic wrapper to an already deployed contract.
+func bindUniswapv3pool(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(Uniswapv3poolABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Uniswapv3pool *Uniswapv3poolRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Uniswapv3pool.Contract.Uniswapv3poolCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Uniswapv3pool *Uniswapv3poolRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Uniswapv3pool.Contract.Uniswapv3poolTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Uniswapv3pool *Uniswapv3poolRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Uniswapv3pool.Contract.Uniswapv3poolTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Uniswapv3pool *Uniswapv3poolCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Uniswapv3pool.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Uniswapv3pool *Uniswapv3poolTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Uniswapv3pool.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Uniswapv3pool *Uniswapv3poolTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Uniswapv3pool.Contract.contract.Transact(opts, method, params...)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolCaller) Factory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "factory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolSession) Factory() (common.Address, error) {
+	return _Uniswapv3pool.Contract.Factory(&_Uniswapv3pool.CallOpts)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) Factory() (common.Address, error) {
+	return _Uniswapv3pool.Contract.Factory(&_Uniswapv3pool.CallOpts)
+}
+
+// Fee is a free data retrieval call binding the contract method 0xddca3f43.
+//
+// Solidity: function fee() view returns(uint24)
+func (_Uniswapv3pool *Uniswapv3poolCaller) Fee(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "fee")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Fee is a free data retrieval call binding the contract method 0xddca3f43.
+//
+// Solidity: function fee() view returns(uint24)
+func (_Uniswapv3pool *Uniswapv3poolSession) Fee() (*big.Int, error) {
+	return _Uniswapv3pool.Contract.Fee(&_Uniswapv3pool.CallOpts)
+}
+
+// Fee is a free data retrieval call binding the contract method 0xddca3f43.
+//
+// Solidity: function fee() view returns(uint24)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) Fee() (*big.Int, error) {
+	return _Uniswapv3pool.Contract.Fee(&_Uniswapv3pool.CallOpts)
+}
+
+// Liquidity is a free data retrieval call binding the contract method 0x1a686502.
+//
+// Solidity: function liquidity() view returns(uint128)
+func (_Uniswapv3pool *Uniswapv3poolCaller) Liquidity(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "liquidity")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Liquidity is a free data retrieval call binding the contract method 0x1a686502.
+//
+// Solidity: function liquidity() view returns(uint128)
+func (_Uniswapv3pool *Uniswapv3poolSession) Liquidity() (*big.Int, error) {
+	return _Uniswapv3pool.Contract.Liquidity(&_Uniswapv3pool.CallOpts)
+}
+
+// Liquidity is a free data retrieval call binding the contract method 0x1a686502.
+//
+// Solidity: function liquidity() view returns(uint128)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) Liquidity() (*big.Int, error) {
+	return _Uniswapv3pool.Contract.Liquidity(&_Uniswapv3pool.CallOpts)
+}
+
+// Slot0 is a free data retrieval call binding the contract method 0x3850c7bd.
+//
+// Solidity: function slot0() view returns(uint160 sqrtPriceX96, int24 tick, uint16 observationIndex, uint16 observationCardinality, uint16 observationCardinalityNext, uint8 feeProtocol, bool unlocked)
+func (_Uniswapv3pool *Uniswapv3poolCaller) Slot0(opts *bind.CallOpts) (struct {
+	SqrtPriceX96               *big.Int
+	Tick                       *big.Int
+	ObservationIndex           uint16
+	ObservationCardinality     uint16
+	ObservationCardinalityNext uint16
+	FeeProtocol                uint8
+	Unlocked                   bool
+}, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "slot0")
+
+	outstruct := new(struct {
+		SqrtPriceX96               *big.Int
+		Tick                       *big.Int
+		ObservationIndex           uint16
+		ObservationCardinality     uint16
+		ObservationCardinalityNext uint16
+		FeeProtocol                uint8
+		Unlocked                   bool
+	})
+
+	outstruct.SqrtPriceX96 = out[0].(*big.Int)
+	outstruct.Tick = out[1].(*big.Int)
+	outstruct.ObservationIndex = out[2].(uint16)
+	outstruct.ObservationCardinality = out[3].(uint16)
+	outstruct.ObservationCardinalityNext = out[4].(uint16)
+	outstruct.FeeProtocol = out[5].(uint8)
+	outstruct.Unlocked = out[6].(bool)
+
+	return *outstruct, err
+
+}
+
+// Slot0 is a free data retrieval call binding the contract method 0x3850c7bd.
+//
+// Solidity: function slot0() view returns(uint160 sqrtPriceX96, int24 tick, uint16 observationIndex, uint16 observationCardinality, uint16 observationCardinalityNext, uint8 feeProtocol, bool unlocked)
+func (_Uniswapv3pool *Uniswapv3poolSession) Slot0() (struct {
+	SqrtPriceX96               *big.Int
+	Tick                       *big.Int
+	ObservationIndex           uint16
+	ObservationCardinality     uint16
+	ObservationCardinalityNext uint16
+	FeeProtocol                uint8
+	Unlocked                   bool
+}, error) {
+	return _Uniswapv3pool.Contract.Slot0(&_Uniswapv3pool.CallOpts)
+}
+
+// Slot0 is a free data retrieval call binding the contract method 0x3850c7bd.
+//
+// Solidity: function slot0() view returns(uint160 sqrtPriceX96, int24 tick, uint16 observationIndex, uint16 observationCardinality, uint16 observationCardinalityNext, uint8 feeProtocol, bool unlocked)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) Slot0() (struct {
+	SqrtPriceX96               *big.Int
+	Tick                       *big.Int
+	ObservationIndex           uint16
+	ObservationCardinality     uint16
+	ObservationCardinalityNext uint16
+	FeeProtocol                uint8
+	Unlocked                   bool
+}, error) {
+	return _Uniswapv3pool.Contract.Slot0(&_Uniswapv3pool.CallOpts)
+}
+
+// TickBitmap is a free data retrieval call binding the contract method 0x5339c296.
+//
+// Solidity: function tickBitmap(int16 ) view returns(uint256)
+func (_Uniswapv3pool *Uniswapv3poolCaller) TickBitmap(opts *bind.CallOpts, arg0 int16) (*big.Int, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "tickBitmap", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TickBitmap is a free data retrieval call binding the contract method 0x5339c296.
+//
+// Solidity: function tickBitmap(int16 ) view returns(uint256)
+func (_Uniswapv3pool *Uniswapv3poolSession) TickBitmap(arg0 int16) (*big.Int, error) {
+	return _Uniswapv3pool.Contract.TickBitmap(&_Uniswapv3pool.CallOpts, arg0)
+}
+
+// TickBitmap is a free data retrieval call binding the contract method 0x5339c296.
+//
+// Solidity: function tickBitmap(int16 ) view returns(uint256)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) TickBitmap(arg0 int16) (*big.Int, error) {
+	return _Uniswapv3pool.Contract.TickBitmap(&_Uniswapv3pool.CallOpts, arg0)
+}
+
+// TickSpacing is a free data retrieval call binding the contract method 0xd0c93a7c.
+//
+// Solidity: function tickSpacing() view returns(int24)
+func (_Uniswapv3pool *Uniswapv3poolCaller) TickSpacing(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "tickSpacing")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TickSpacing is a free data retrieval call binding the contract method 0xd0c93a7c.
+//
+// Solidity: function tickSpacing() view returns(int24)
+func (_Uniswapv3pool *Uniswapv3poolSession) TickSpacing() (*big.Int, error) {
+	return _Uniswapv3pool.Contract.TickSpacing(&_Uniswapv3pool.CallOpts)
+}
+
+// TickSpacing is a free data retrieval call binding the contract method 0xd0c93a7c.
+//
+// Solidity: function tickSpacing() view returns(int24)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) TickSpacing() (*big.Int, error) {
+	return _Uniswapv3pool.Contract.TickSpacing(&_Uniswapv3pool.CallOpts)
+}
+
+// Ticks is a free data retrieval call binding the contract method 0xf30dba93.
+//
+// Solidity: function ticks(int24 ) view returns(uint128 liquidityGross, int128 liquidityNet, uint256 feeGrowthOutside0X128, uint256 feeGrowthOutside1X128, int56 tickCumulativeOutside, uint160 secondsPerLiquidityOutsideX128, uint32 secondsOutside, bool initialized)
+func (_Uniswapv3pool *Uniswapv3poolCaller) Ticks(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	LiquidityGross                 *big.Int
+	LiquidityNet                   *big.Int
+	FeeGrowthOutside0X128          *big.Int
+	FeeGrowthOutside1X128          *big.Int
+	TickCumulativeOutside          *big.Int
+	SecondsPerLiquidityOutsideX128 *big.Int
+	SecondsOutside                 uint32
+	Initialized                    bool
+}, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "ticks", arg0)
+
+	outstruct := new(struct {
+		LiquidityGross                 *big.Int
+		LiquidityNet                   *big.Int
+		FeeGrowthOutside0X128          *big.Int
+		FeeGrowthOutside1X128          *big.Int
+		TickCumulativeOutside          *big.Int
+		SecondsPerLiquidityOutsideX128 *big.Int
+		SecondsOutside                 uint32
+		Initialized                    bool
+	})
+
+	outstruct.LiquidityGross = out[0].(*big.Int)
+	outstruct.LiquidityNet = out[1].(*big.Int)
+	outstruct.FeeGrowthOutside0X128 = out[2].(*big.Int)
+	outstruct.FeeGrowthOutside1X128 = out[3].(*big.Int)
+	outstruct.TickCumulativeOutside = out[4].(*big.Int)
+	outstruct.SecondsPerLiquidityOutsideX128 = out[5].(*big.Int)
+	outstruct.SecondsOutside = out[6].(uint32)
+	outstruct.Initialized = out[7].(bool)
+
+	return *outstruct, err
+
+}
+
+// Ticks is a free data retrieval call binding the contract method 0xf30dba93.
+//
+// Solidity: function ticks(int24 ) view returns(uint128 liquidityGross, int128 liquidityNet, uint256 feeGrowthOutside0X128, uint256 feeGrowthOutside1X128, int56 tickCumulativeOutside, uint160 secondsPerLiquidityOutsideX128, uint32 secondsOutside, bool initialized)
+func (_Uniswapv3pool *Uniswapv3poolSession) Ticks(arg0 *big.Int) (struct {
+	LiquidityGross                 *big.Int
+	LiquidityNet                   *big.Int
+	FeeGrowthOutside0X128          *big.Int
+	FeeGrowthOutside1X128          *big.Int
+	TickCumulativeOutside          *big.Int
+	SecondsPerLiquidityOutsideX128 *big.Int
+	SecondsOutside                 uint32
+	Initialized                    bool
+}, error) {
+	return _Uniswapv3pool.Contract.Ticks(&_Uniswapv3pool.CallOpts, arg0)
+}
+
+// Ticks is a free data retrieval call binding the contract method 0xf30dba93.
+//
+// Solidity: function ticks(int24 ) view returns(uint128 liquidityGross, int128 liquidityNet, uint256 feeGrowthOutside0X128, uint256 feeGrowthOutside1X128, int56 tickCumulativeOutside, uint160 secondsPerLiquidityOutsideX128, uint32 secondsOutside, bool initialized)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) Ticks(arg0 *big.Int) (struct {
+	LiquidityGross                 *big.Int
+	LiquidityNet                   *big.Int
+	FeeGrowthOutside0X128          *big.Int
+	FeeGrowthOutside1X128          *big.Int
+	TickCumulativeOutside          *big.Int
+	SecondsPerLiquidityOutsideX128 *big.Int
+	SecondsOutside                 uint32
+	Initialized                    bool
+}, error) {
+	return _Uniswapv3pool.Contract.Ticks(&_Uniswapv3pool.CallOpts, arg0)
+}
+
+// Token0 is a free data retrieval call binding the contract method 0x0dfe1681.
+//
+// Solidity: function token0() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolCaller) Token0(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "token0")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Token0 is a free data retrieval call binding the contract method 0x0dfe1681.
+//
+// Solidity: function token0() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolSession) Token0() (common.Address, error) {
+	return _Uniswapv3pool.Contract.Token0(&_Uniswapv3pool.CallOpts)
+}
+
+// Token0 is a free data retrieval call binding the contract method 0x0dfe1681.
+//
+// Solidity: function token0() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) Token0() (common.Address, error) {
+	return _Uniswapv3pool.Contract.Token0(&_Uniswapv3pool.CallOpts)
+}
+
+// Token1 is a free data retrieval call binding the contract method 0xd21220a7.
+//
+// Solidity: function token1() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolCaller) Token1(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Uniswapv3pool.contract.Call(opts, &out, "token1")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Token1 is a free data retrieval call binding the contract method 0xd21220a7.
+//
+// Solidity: function token1() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolSession) Token1() (common.Address, error) {
+	return _Uniswapv3pool.Contract.Token1(&_Uniswapv3pool.CallOpts)
+}
+
+// Token1 is a free data retrieval call binding the contract method 0xd21220a7.
+//
+// Solidity: function token1() view returns(address)
+func (_Uniswapv3pool *Uniswapv3poolCallerSession) Token1() (common.Address, error) {
+	return _Uniswapv3pool.Contract.Token1(&_Uniswapv3pool.CallOpts)
+}
+
+// Uniswapv3poolSwapIterator is returned from FilterSwap and is used to iterate over the raw logs and unpacked data for Swap events raised by the Uniswapv3pool contract.
+type Uniswapv3poolSwapIterator struct {
+	Event *Uniswapv3poolSwap // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *Uniswapv3poolSwapIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(Uniswapv3poolSwap)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(Uniswapv3poolSwap)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *Uniswapv3poolSwapIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *Uniswapv3poolSwapIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// Uniswapv3poolSwap represents a Swap event raised by the Uniswapv3pool contract.
+type Uniswapv3poolSwap struct {
+	Sender       common.Address
+	Recipient    common.Address
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int
+	Liquidity    *big.Int
+	Tick         *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterSwap is a free log retrieval operation binding the contract event 0xc42079f94a6350d7e6235f29174924f928cc2ac818eb64fed8004e115fbcca67.
+//
+// Solidity: event Swap(address indexed sender, address indexed recipient, int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick)
+func (_Uniswapv3pool *Uniswapv3poolFilterer) FilterSwap(opts *bind.FilterOpts, sender []common.Address, recipient []common.Address) (*Uniswapv3poolSwapIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _Uniswapv3pool.contract.FilterLogs(opts, "Swap", senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &Uniswapv3poolSwapIterator{contract: _Uniswapv3pool.contract, event: "Swap", logs: logs, sub: sub}, nil
+}
+
+// WatchSwap is a free log subscription operation binding the contract event 0xc42079f94a6350d7e6235f29174924f928cc2ac818eb64fed8004e115fbcca67.
+//
+// Solidity: event Swap(address indexed sender, address indexed recipient, int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick)
+func (_Uniswapv3pool *Uniswapv3poolFilterer) WatchSwap(opts *bind.WatchOpts, sink chan<- *Uniswapv3poolSwap, sender []common.Address, recipient []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _Uniswapv3pool.contract.WatchLogs(opts, "Swap", senderRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(Uniswapv3poolSwap)
+				if err := _Uniswapv3pool.contract.UnpackLog(event, "Swap", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSwap is a log parse operation binding the contract event 0xc42079f94a6350d7e6235f29174924f928cc2ac818eb64fed8004e115fbcca67.
+//
+// Solidity: event Swap(address indexed sender, address indexed recipient, int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick)
+func (_Uniswapv3pool *Uniswapv3poolFilterer) ParseSwap(log types.Log) (*Uniswapv3poolSwap, error) {
+	event := new(Uniswapv3poolSwap)
+	if err := _Uniswapv3pool.contract.UnpackLog(event, "Swap", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
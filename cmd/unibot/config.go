@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/urfave/cli/v2"
+)
+
+var configCommand = &cli.Command{
+	Name:  "config",
+	Usage: "inspect the bot configuration file",
+	Subcommands: cli.Commands{
+		&cli.Command{
+			Name:  "validate",
+			Usage: "checks the config file for errors, verifying watchers against the chain unless offline",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "offline",
+					Usage: "skip the checks that connect to the chain",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				path := c.String("config")
+				data, err := ioutil.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				// decoding errors include the line of the offending field
+				cfg, err := loadConfig(c)
+				if err != nil {
+					return err
+				}
+				problems := cfg.Problems()
+				// the chain is only checked once the networks are known to be usable
+				if !c.Bool("offline") && len(problems) == 0 {
+					bc, err := newClient(cfg)
+					if err != nil {
+						return err
+					}
+					defer bc.Close()
+					clients, err := newWatcherClients(cfg, bc)
+					if err != nil {
+						return err
+					}
+					defer func() {
+						for _, client := range clients[1:] {
+							client.Close()
+						}
+					}()
+					// names are resolved first as the chain checks need the addresses
+					if problems = cfg.ResolveNames(bc); len(problems) == 0 {
+						problems = cfg.ChainProblems(clients...)
+					}
+				}
+				if len(problems) == 0 {
+					fmt.Printf("%s is valid\n", path)
+					return nil
+				}
+				discord.Locate(data, problems)
+				for _, problem := range problems {
+					if problem.Line > 0 {
+						fmt.Printf("%s:%d: %s: %s\n", path, problem.Line, problem.Path, problem.Err)
+					} else {
+						fmt.Printf("%s: %s\n", path, problem)
+					}
+				}
+				return cli.Exit(fmt.Sprintf("found %d problems in %s", len(problems), path), 1)
+			},
+		},
+	},
+}
+
+// loadConfig loads the config in layers: the config file, then the UNIBOT_ environment variables
+// and secret files, then the command line flags. Without a config file the layers start from an
+// empty config, unless the config flag was explicitly set
+func loadConfig(c *cli.Context) (*discord.Config, error) {
+	cfg := &discord.Config{}
+	path := c.String("config")
+	if _, err := os.Stat(path); err == nil || c.IsSet("config") {
+		if cfg, err = discord.LoadConfig(path); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if err := cfg.ApplyEnv(); err != nil {
+		return nil, err
+	}
+	if c.IsSet("eth.network") {
+		cfg.Network = c.String("eth.network")
+	}
+	if c.IsSet("infura.api_key") {
+		cfg.InfuraAPIKey = c.String("infura.api_key")
+		if strings.Contains(cfg.InfuraAPIKey, "wss") {
+			cfg.InfuraWSEnabled = true
+		}
+		// the flag selects infura over the configured endpoint unless eth.rpc is also set
+		cfg.ETHRPCEndpoint = ""
+	}
+	if c.IsSet("eth.rpc") {
+		cfg.ETHRPCEndpoint = c.String("eth.rpc")
+	}
+	if c.IsSet("discord.token") {
+		cfg.DiscordToken = c.String("discord.token")
+	}
+	return cfg, nil
+}
+
+// checkConfig resolves the ENS names used in the config and checks the watchers against the chain,
+// returning the first problem found so configs are rejected at startup like config validate would
+func checkConfig(cfg *discord.Config, bc *bclient.Client) error {
+	if err := resolveNames(cfg, bc); err != nil {
+		return err
+	}
+	clients, err := newWatcherClients(cfg, bc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, client := range clients[1:] {
+			client.Close()
+		}
+	}()
+	if problems := cfg.ChainProblems(clients...); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// resolveNames resolves the ENS names used in place of addresses in the config, returning the
+// first name that doesn't resolve
+func resolveNames(cfg *discord.Config, bc *bclient.Client) error {
+	if problems := cfg.ResolveNames(bc); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
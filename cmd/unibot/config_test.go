@@ -0,0 +1,61 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "unibot")
+	require.NoError(t, err)
+	path := filepath.Join(dir, "config.yml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("infura_api_key: from-file\neth_rpc_endpoint: http://localhost:8545\n"), 0644))
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		os.Unsetenv("INFURA_API_KEY")
+		os.Unsetenv("UNIBOT_INFURA_API_KEY")
+	})
+	load := func(args ...string) *discord.Config {
+		var cfg *discord.Config
+		app := cli.NewApp()
+		app.Flags = []cli.Flag{
+			&cli.StringFlag{Name: "config", Value: path},
+			&cli.StringFlag{Name: "infura.api_key"},
+			&cli.StringFlag{Name: "eth.rpc"},
+			&cli.StringFlag{Name: "eth.network"},
+			&cli.StringFlag{Name: "discord.token"},
+		}
+		app.Action = func(c *cli.Context) error {
+			var err error
+			cfg, err = loadConfig(c)
+			return err
+		}
+		require.NoError(t, app.Run(append([]string{"unibot"}, args...)))
+		return cfg
+	}
+
+	// the legacy variable overrides the file without clearing its endpoint
+	require.NoError(t, os.Setenv("INFURA_API_KEY", "from-legacy-env"))
+	cfg := load()
+	require.Equal(t, "from-legacy-env", cfg.InfuraAPIKey)
+	require.Equal(t, "http://localhost:8545", cfg.ETHRPCEndpoint)
+
+	// UNIBOT_ variables take precedence over the legacy variable
+	require.NoError(t, os.Setenv("UNIBOT_INFURA_API_KEY", "from-env"))
+	cfg = load()
+	require.Equal(t, "from-env", cfg.InfuraAPIKey)
+	require.Equal(t, "http://localhost:8545", cfg.ETHRPCEndpoint)
+
+	// flags take precedence over everything, selecting infura over the endpoint
+	cfg = load("--infura.api_key", "from-flag")
+	require.Equal(t, "from-flag", cfg.InfuraAPIKey)
+	require.Empty(t, cfg.ETHRPCEndpoint)
+	cfg = load("--infura.api_key", "from-flag", "--eth.rpc", "http://node:8545")
+	require.Equal(t, "http://node:8545", cfg.ETHRPCEndpoint)
+}
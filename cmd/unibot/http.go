@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	// registers the /debug/vars metrics handler
+	_ "expvar"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/health"
+)
+
+// serveHTTP serves the default mux and the health checks on addr in the background, returning
+// nil if addr is empty
+func serveHTTP(addr string, checker *health.Checker) *http.Server {
+	if addr == "" {
+		return nil
+	}
+	checker.Register(http.DefaultServeMux)
+	server := &http.Server{Addr: addr}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Println("http server failed: ", err)
+		}
+	}()
+	log.Printf("serving metrics and health checks on %s\n", addr)
+	return server
+}
+
+// newChecker returns a health checker of the client's node and the database. The node is stuck,
+// and the process should be restarted, if its head block is older than the config's stale threshold
+func newChecker(cfg *discord.Config, bc *bclient.Client, database *db.Database) *health.Checker {
+	staleAfter := cfg.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = time.Minute * 5
+	}
+	checker := health.NewChecker(time.Second * 5)
+	checker.Liveness(func(ctx context.Context) []health.Status {
+		status := health.Status{Component: "rpc:" + bc.Network().Name}
+		head, err := bc.Backend().HeaderByNumber(ctx, nil)
+		if err != nil {
+			status.Error = err.Error()
+			return []health.Status{status}
+		}
+		updated := time.Unix(int64(head.Time), 0)
+		status.Updated, status.Block = &updated, head.Number.Uint64()
+		if age := time.Since(updated); age > staleAfter {
+			status.Error = fmt.Sprintf("head block is %s old", age.Round(time.Second))
+		} else {
+			status.Healthy = true
+		}
+		return []health.Status{status}
+	})
+	checker.Readiness(health.Func("db", database.Ping))
+	return checker
+}
+
+// leaderID identifies this process in leader election
+func leaderID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
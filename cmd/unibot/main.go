@@ -2,18 +2,22 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
-	"strings"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/bonedaddy/unibot/bclient"
 	"github.com/bonedaddy/unibot/db"
 	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/pricing"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
 	"github.com/bonedaddy/unibot/watcher"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
@@ -29,18 +33,20 @@ func main() {
 	app.Version = Version
 	app.Flags = []cli.Flag{
 		&cli.StringFlag{
-			Name:    "infura.api_key",
-			Usage:   "api key for use with infura",
-			EnvVars: []string{"INFURA_API_KEY"},
+			Name:  "infura.api_key",
+			Usage: "api key for use with infura, overriding infura_api_key in the config and the INFURA_API_KEY environment variable",
 		},
 		&cli.StringFlag{
 			Name:  "eth.rpc",
-			Usage: "specifies the ethereum RPC endpoint",
-			Value: bclient.InfuraHTTPURL,
+			Usage: "specifies the ethereum RPC endpoint, overriding eth_rpc_endpoint in the config",
+		},
+		&cli.StringFlag{
+			Name:  "eth.network",
+			Usage: "name or chain id of the network the ethereum RPC endpoint is on, overriding network in the config",
 		},
 		&cli.StringFlag{
 			Name:  "eth.address",
-			Usage: "address to lookup in queries",
+			Usage: "address or ENS name to lookup in queries",
 			Value: "0x5a361A1dfd52538A158e352d21B5b622360a7C13",
 		},
 		&cli.StringFlag{
@@ -64,22 +70,167 @@ func main() {
 		if c.Bool("startup.sleep") {
 			time.Sleep(c.Duration("startup.sleep_time"))
 		}
-		if c.String("config") != "" {
-			return nil
-		}
-		var err error
-		if c.String("infura.api_key") != "" {
-			var websockets bool
-			if strings.Contains(c.String("infura.api_key"), "wss") {
-				websockets = true
-			}
-			bc, err = bclient.NewInfuraClient(c.String("infura.api_key"), websockets)
-		} else {
-			bc, err = bclient.NewClient(c.String("eth.rpc"))
-		}
-		return err
+		return nil
 	}
 	app.Commands = cli.Commands{
+		tradeCommand,
+		configCommand,
+		&cli.Command{
+			Name:      "depth",
+			Usage:     "prints the trade size needed to move the price of a pair and an order book ladder",
+			ArgsUsage: "<token0> <token1>",
+			Flags: []cli.Flag{
+				&cli.Float64Flag{
+					Name:  "step",
+					Usage: "price step between order book levels as a fraction",
+					Value: 0.01,
+				},
+				&cli.IntFlag{
+					Name:  "levels",
+					Usage: "number of order book levels either side of the price",
+					Value: 10,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 2 {
+					return errors.New("expected token0 and token1")
+				}
+				client, err := loadClient(c)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				token0, token1, err := resolveTokens(client, c.Args().Get(0), c.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				depth, err := client.Depth(token0, token1, c.Float64("step"), c.Int("levels"))
+				if err != nil {
+					return err
+				}
+				fmt.Print(depth.Table())
+				return nil
+			},
+		},
+		&cli.Command{
+			Name:      "route",
+			Usage:     "compares swapping through the uniswap v2 pair and every v3 fee tier",
+			ArgsUsage: "<tokenIn> <tokenOut> <amount>",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 3 {
+					return errors.New("expected tokenIn, tokenOut and amount")
+				}
+				client, err := loadClient(c)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				tokenIn, tokenOut, err := resolveTokens(client, c.Args().Get(0), c.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				tknIn, err := client.TokenInfo(common.HexToAddress(tokenIn))
+				if err != nil {
+					return err
+				}
+				tknOut, err := client.TokenInfo(common.HexToAddress(tokenOut))
+				if err != nil {
+					return err
+				}
+				amountIn, err := utils.ParseAmount(c.Args().Get(2), tknIn.Decimals)
+				if err != nil {
+					return err
+				}
+				quotes, err := client.Uniswap().CompareVenues(amountIn, tknIn.Address, tknOut.Address)
+				if err != nil {
+					if errors.Is(err, uniswap.ErrPairNotFound) {
+						return fmt.Errorf("no such pair %s/%s", tokenIn, tokenOut)
+					}
+					return err
+				}
+				for _, quote := range quotes {
+					fmt.Printf("%s\t%s %s\n", quote.Venue, utils.ToDecimal(quote.AmountOut, tknOut.Decimals), tknOut.Symbol)
+				}
+				return nil
+			},
+		},
+		&cli.Command{
+			Name:      "price",
+			Usage:     "returns the current price of token0 denominated in token1",
+			ArgsUsage: "<token0> <token1>",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 2 {
+					return errors.New("expected token0 and token1 addresses")
+				}
+				client, err := loadClient(c)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				token0, token1, err := resolveTokens(client, c.Args().Get(0), c.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				price, err := client.PairPrice(token0, token1)
+				if err != nil {
+					if errors.Is(err, uniswap.ErrPairNotFound) {
+						return fmt.Errorf("no such pair %s/%s", token0, token1)
+					}
+					return err
+				}
+				currency, err := pricing.ParseCurrency(c.String("currency"))
+				if err != nil {
+					return err
+				}
+				price, err = pricing.NewConverter(client, nil).Convert(price, common.HexToAddress(token1), currency)
+				if err != nil {
+					return err
+				}
+				fmt.Println(price)
+				return nil
+			},
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "currency",
+					Usage: "currency to display the price in, one of usd, eth, dai or native",
+					Value: "usd",
+				},
+			},
+		},
+		&cli.Command{
+			Name:      "portfolio",
+			Usage:     "prints the USD value of an address's token and LP token balances",
+			ArgsUsage: "[address]",
+			Action: func(c *cli.Context) error {
+				address := c.String("eth.address")
+				if c.NArg() > 0 {
+					address = c.Args().Get(0)
+				}
+				cfg, err := loadConfig(c)
+				if err != nil {
+					return err
+				}
+				client, err := newClient(cfg)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				if err := resolveNames(cfg, client); err != nil {
+					return err
+				}
+				account, err := client.ResolveAddress(address)
+				if err != nil {
+					return err
+				}
+				tokens, pairs := cfg.PortfolioAssets(client.Network())
+				portfolio, err := client.Portfolio(account, tokens, pairs)
+				if err != nil {
+					return err
+				}
+				fmt.Print(portfolio.Table())
+				return nil
+			},
+		},
 		&cli.Command{
 			Name:  "discord",
 			Usage: "discord bot management",
@@ -96,19 +247,21 @@ func main() {
 							Action: func(c *cli.Context) error {
 								ctx, cancel := context.WithCancel(c.Context)
 								defer cancel()
-								cfg, err := discord.LoadConfig(c.String("config"))
+								cfg, err := loadConfig(c)
 								if err != nil {
 									return err
 								}
-								if cfg.InfuraAPIKey != "" {
-									bc, err = bclient.NewInfuraClient(cfg.InfuraAPIKey, cfg.InfuraWSEnabled)
-								} else {
-									bc, err = bclient.NewClient(cfg.ETHRPCEndpoint)
+								if err := cfg.Validate(); err != nil {
+									return err
 								}
+								bc, err = newClient(cfg)
 								if err != nil {
 									return err
 								}
 								defer bc.Close()
+								if err := checkConfig(cfg, bc); err != nil {
+									return err
+								}
 								database, err := db.New(&db.Opts{
 									Type:           cfg.Database.Type,
 									Host:           cfg.Database.Host,
@@ -125,21 +278,71 @@ func main() {
 								if err := database.AutoMigrate(); err != nil {
 									return err
 								}
-								items := watcher.ConfigToWatchItmes(cfg)
-								watchService := watcher.New(ctx, database, bc, time.Second*5, items)
-								watchService.Start()
+								updater := &chainUpdater{ctx: ctx, bc: bc, database: database, cfg: cfg}
+								var leader *watcher.Leader
+								if c.Bool("leader.election") {
+									lock, err := database.LeaderLock("chain-updater", leaderID(), c.Duration("leader.ttl"))
+									if err != nil {
+										return err
+									}
+									// renewing three times per ttl lets the leader miss a renewal without losing the lock
+									leader = watcher.NewLeader(ctx, "chain-updater", lock, c.Duration("leader.ttl")/3, updater.Start, updater.Stop)
+									leader.Start()
+								} else if err := updater.Start(); err != nil {
+									return err
+								}
+								// stop steps down or stops the updater before the database is closed
+								stop := func() {
+									if leader != nil {
+										leader.Stop()
+									} else {
+										updater.Stop()
+									}
+								}
+								checker := newChecker(cfg, bc, database)
+								checker.Liveness(updater.Health)
+								server := serveHTTP(c.String("http.addr"), checker)
+								if err := discord.WatchConfig(ctx, c.String("config"), reloader(c), func(next *discord.Config) {
+									if err := resolveNames(next, bc); err != nil {
+										log.Println("rejected config reload: ", err)
+										return
+									}
+									updater.Reload(next)
+								}); err != nil {
+									stop()
+									return err
+								}
 								sc := make(chan os.Signal, 1)
 								signal.Notify(sc, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, os.Interrupt, os.Kill)
 								<-sc
-								watchService.Stop()
+								stop()
+								if server != nil {
+									server.Close()
+								}
 								return nil
 							},
+							Flags: []cli.Flag{
+								&cli.BoolFlag{
+									Name:  "leader.election",
+									Usage: "elect a leader through the database so only one of several replicas writes to it",
+									Value: true,
+								},
+								&cli.DurationFlag{
+									Name:  "leader.ttl",
+									Usage: "how long a leader that stopped renewing its lease is waited for before a standby takes over",
+									Value: time.Second * 15,
+								},
+								&cli.StringFlag{
+									Name:  "http.addr",
+									Usage: "address to serve metrics on at /debug/vars and health checks at /healthz and /readyz, disabled if empty",
+								},
+							},
 						},
 					},
 				},
 				&cli.Command{
 					Name:  "gen-config",
-					Usage: "generate ndx bot config file",
+					Usage: "generate ndx bot config file, with secrets referencing UNIBOT_ environment variables",
 					Action: func(c *cli.Context) error {
 						return discord.NewConfig(c.String("config"))
 					},
@@ -150,19 +353,21 @@ func main() {
 					Action: func(c *cli.Context) error {
 						ctx, cancel := context.WithCancel(c.Context)
 						defer cancel()
-						cfg, err := discord.LoadConfig(c.String("config"))
+						cfg, err := loadConfig(c)
 						if err != nil {
 							return err
 						}
-						if cfg.InfuraAPIKey != "" {
-							bc, err = bclient.NewInfuraClient(cfg.InfuraAPIKey, cfg.InfuraWSEnabled)
-						} else {
-							bc, err = bclient.NewClient(cfg.ETHRPCEndpoint)
+						if err := cfg.Validate(); err != nil {
+							return err
 						}
+						bc, err = newClient(cfg)
 						if err != nil {
 							return err
 						}
 						defer bc.Close()
+						if err := checkConfig(cfg, bc); err != nil {
+							return err
+						}
 						database, err := db.New(&db.Opts{
 							Type:           cfg.Database.Type,
 							Host:           cfg.Database.Host,
@@ -180,38 +385,82 @@ func main() {
 
 							return err
 						}
-						wg := &sync.WaitGroup{}
-						if c.Bool("update.database") {
-							wg.Add(1)
-							// launch database price updater loop
-							go func() {
-								defer wg.Done()
-								// dbPriceUpdateLoop(ctx, bc, database)
-							}()
-						}
-
 						client, err := discord.NewClient(ctx, cfg, bc, database)
 						if err != nil {
 							return err
 						}
+						var embedded *updater
+						if c.Bool("update.database") {
+							// the updater shares the bot's client and database, and prices are passed to
+							// the watcher bots as they are recorded
+							embedded, err = newUpdater(ctx, cfg, bc, database)
+							if err != nil {
+								client.Close()
+								return err
+							}
+							client.FollowPrices(embedded.service.Subscribe(len(cfg.Watchers) + 1))
+							embedded.Start()
+						} else {
+							interval := cfg.UpdateInterval
+							if interval <= 0 {
+								interval = time.Second * 5
+							}
+							client.PollPrices(interval)
+						}
+						checker := newChecker(cfg, bc, database)
+						if embedded != nil {
+							checker.Liveness(embedded.service.Health)
+						}
+						checker.Readiness(client.Health)
+						server := serveHTTP(c.String("http.addr"), checker)
+						// stopUpdater stops recording prices and serving health checks before the bot and
+						// database are closed
+						stopUpdater := func() {
+							if server != nil {
+								server.Close()
+							}
+							if embedded != nil {
+								embedded.Stop()
+							}
+						}
+						if err := discord.WatchConfig(ctx, c.String("config"), reloader(c), func(next *discord.Config) {
+							if err := resolveNames(next, bc); err != nil {
+								log.Println("rejected config reload: ", err)
+								return
+							}
+							if embedded != nil {
+								if err := embedded.reload(next); err != nil {
+									log.Println("rejected config reload: ", err)
+									return
+								}
+							}
+							client.UpdateWatchers(next.Watchers)
+						}); err != nil {
+							stopUpdater()
+							client.Close()
+							return err
+						}
 						sc := make(chan os.Signal, 1)
 						signal.Notify(sc, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, os.Interrupt, os.Kill)
 						<-sc
+						stopUpdater()
 						cancel()
-						wg.Wait()
 						return client.Close()
 					},
 					Flags: []cli.Flag{
 						&cli.StringFlag{
-							Name:    "discord.token",
-							Usage:   "the discord api token",
-							EnvVars: []string{"DISCORD_TOKEN"},
+							Name:  "discord.token",
+							Usage: "the discord api token, overriding discord_token in the config and the DISCORD_TOKEN environment variable",
 						},
 						&cli.BoolFlag{
 							Name:  "update.database",
-							Usage: "if true launch the db price update routine. if false make sure chain-updater command is running",
+							Usage: "if true record prices in this process, showing them on the watcher bots as they are recorded. if false make sure chain-updater command is running",
 							Value: true,
 						},
+						&cli.StringFlag{
+							Name:  "http.addr",
+							Usage: "address to serve metrics on at /debug/vars and health checks at /healthz and /readyz, disabled if empty",
+						},
 					},
 				},
 			},
@@ -221,3 +470,33 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// loadClient returns a blockchain client using the layered config, see loadConfig
+func loadClient(c *cli.Context) (*bclient.Client, error) {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(cfg)
+}
+
+// reloader returns a loader reloading the layered config, so the environment and command line
+// flags applied at startup are also applied to the reloaded config file
+func reloader(c *cli.Context) func() (*discord.Config, error) {
+	return func() (*discord.Config, error) {
+		return loadConfig(c)
+	}
+}
+
+// resolveTokens returns the hex addresses of two token arguments, which may be ENS names
+func resolveTokens(client *bclient.Client, token0, token1 string) (string, string, error) {
+	address0, err := client.ResolveAddress(token0)
+	if err != nil {
+		return "", "", err
+	}
+	address1, err := client.ResolveAddress(token1)
+	if err != nil {
+		return "", "", err
+	}
+	return address0.Hex(), address1.Hex(), nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/network"
+)
+
+// newClient returns a blockchain client connected to the node specified in the config
+func newClient(cfg *discord.Config) (*bclient.Client, error) {
+	net, err := network.Lookup(cfg.Network)
+	if err != nil {
+		return nil, err
+	}
+	return newNetworkClient(cfg, net)
+}
+
+// newNetworkClient returns a blockchain client connected to the given network, using the
+// endpoint configured for it, then infura, then the network's public endpoint
+func newNetworkClient(cfg *discord.Config, net *network.Network) (*bclient.Client, error) {
+	if rpc := cfg.NetworkRPC(net); rpc != "" {
+		return bclient.Dial(rpc, net)
+	}
+	if cfg.InfuraAPIKey != "" && net.InfuraHTTPURL != "" {
+		return bclient.NewNetworkInfuraClient(net, cfg.InfuraAPIKey, cfg.InfuraWSEnabled)
+	}
+	if net.RPCURL != "" {
+		return bclient.Dial(net.RPCURL, net)
+	}
+	return nil, fmt.Errorf("no endpoint configured for %s", net.Name)
+}
+
+// newWatcherClients returns a client for every network a watcher is on, starting with bc which is
+// used for watchers on its network
+func newWatcherClients(cfg *discord.Config, bc *bclient.Client) ([]*bclient.Client, error) {
+	clients := []*bclient.Client{bc}
+	for _, watcher := range cfg.Watchers {
+		net, err := cfg.WatcherNetwork(watcher)
+		if err != nil {
+			return nil, err
+		}
+		var connected bool
+		for _, client := range clients {
+			if client.Network().ChainID == net.ChainID {
+				connected = true
+				break
+			}
+		}
+		if connected {
+			continue
+		}
+		client, err := newNetworkClient(cfg, net)
+		if err != nil {
+			for _, client := range clients[1:] {
+				client.Close()
+			}
+			return nil, fmt.Errorf("failed to connect to %s: %w", net.Name, err)
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
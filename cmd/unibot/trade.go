@@ -0,0 +1,270 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+// tradeFlags select the signing key and whether transactions are simulated or sent
+var tradeFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "keystore",
+		Usage: "path to the keystore file used to sign transactions",
+	},
+	&cli.StringFlag{
+		Name:  "keystore.password_file",
+		Usage: "path to the file containing the keystore password",
+	},
+	&cli.StringFlag{
+		Name:  "key.file",
+		Usage: "path to a file containing a hex encoded private key used to sign transactions",
+	},
+	&cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the calldata and simulate the transaction with eth_call, this is the default",
+	},
+	&cli.BoolFlag{
+		Name:  "send",
+		Usage: "sign and broadcast the transaction",
+	},
+}
+
+var tradeCommand = &cli.Command{
+	Name:  "trade",
+	Usage: "builds, simulates and sends uniswap router02 transactions",
+	Subcommands: cli.Commands{
+		&cli.Command{
+			Name:      "approve",
+			Usage:     "approves the router to spend a token, defaults to an unlimited amount",
+			ArgsUsage: "<token> [amount]",
+			Flags:     tradeFlags,
+			Action: func(c *cli.Context) error {
+				if c.NArg() < 1 || c.NArg() > 2 {
+					return errors.New("expected token and an optional amount")
+				}
+				client, err := loadClient(c)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				address, err := client.ResolveAddress(c.Args().Get(0))
+				if err != nil {
+					return err
+				}
+				token, err := client.TokenInfo(address)
+				if err != nil {
+					return err
+				}
+				amount := math.MaxBig256
+				if c.NArg() == 2 {
+					// a zero amount would revoke the allowance, so malformed amounts are rejected
+					if amount, err = utils.ParseAmount(c.Args().Get(1), token.Decimals); err != nil {
+						return err
+					}
+				}
+				data, err := uniswap.ApproveCalldata(client.Uniswap().Venue().Router, amount)
+				if err != nil {
+					return err
+				}
+				_, err = executeCall(c, client, bclient.Call{To: token.Address, Data: data})
+				return err
+			},
+		},
+		&cli.Command{
+			Name:      "swap",
+			Usage:     "swaps an exact amount of tokenIn, or ETH, for tokenOut",
+			ArgsUsage: "<tokenIn | ETH> <tokenOut> <amount>",
+			Flags: append([]cli.Flag{
+				&cli.Float64Flag{
+					Name:  "slippage",
+					Usage: "maximum slippage from the quoted amount out as a percentage",
+					Value: 0.5,
+				},
+				&cli.DurationFlag{
+					Name:  "deadline",
+					Usage: "how long the transaction may be pending before the swap reverts",
+					Value: time.Minute * 20,
+				},
+				&cli.StringFlag{
+					Name:  "to",
+					Usage: "recipient of the output tokens, defaults to the sender",
+				},
+			}, tradeFlags...),
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 3 {
+					return errors.New("expected tokenIn, tokenOut and amount")
+				}
+				if c.Float64("slippage") < 0 || c.Float64("slippage") >= 100 {
+					return errors.New("slippage must be between 0 and 100")
+				}
+				client, err := loadClient(c)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				// the native currency is swapped by the router after wrapping it
+				wrapped := client.Network().WrappedNative
+				ethIn := strings.EqualFold(c.Args().Get(0), "ETH") ||
+					strings.EqualFold(c.Args().Get(0), client.Network().NativeSymbol)
+				tokenIn := wrapped
+				if !ethIn {
+					if tokenIn, err = client.ResolveAddress(c.Args().Get(0)); err != nil {
+						return err
+					}
+				}
+				tokenOut, err := client.ResolveAddress(c.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				tknIn, err := client.TokenInfo(tokenIn)
+				if err != nil {
+					return err
+				}
+				tknOut, err := client.TokenInfo(tokenOut)
+				if err != nil {
+					return err
+				}
+				path, err := client.Uniswap().SwapPath(tknIn.Address, tknOut.Address, wrapped)
+				if err != nil {
+					return err
+				}
+				amountIn, err := utils.ParseAmount(c.Args().Get(2), tknIn.Decimals)
+				if err != nil {
+					return err
+				}
+				amounts, err := client.Uniswap().GetAmountsOut(amountIn, path)
+				if err != nil {
+					return err
+				}
+				amountOut := amounts[len(amounts)-1]
+				sender, err := tradeSender(c, client)
+				if err != nil {
+					return err
+				}
+				to := sender
+				if c.String("to") != "" {
+					if to, err = client.ResolveAddress(c.String("to")); err != nil {
+						return fmt.Errorf("invalid recipient: %w", err)
+					}
+				}
+				params := uniswap.SwapParams{
+					Path:         path,
+					AmountIn:     amountIn,
+					AmountOutMin: uniswap.AmountOutMin(amountOut, int64(c.Float64("slippage")*100)),
+					To:           to,
+					Deadline:     time.Now().Add(c.Duration("deadline")),
+					ETHIn:        ethIn,
+				}
+				data, err := uniswap.SwapCalldata(params)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("quote: %s %s\n", utils.ToDecimal(amountOut, tknOut.Decimals), tknOut.Symbol)
+				fmt.Printf("minimum out: %s %s\n", utils.ToDecimal(params.AmountOutMin, tknOut.Decimals), tknOut.Symbol)
+				call := bclient.Call{To: client.Uniswap().Venue().Router, Data: data}
+				if ethIn {
+					call.Value = amountIn
+				}
+				result, err := executeCall(c, client, call)
+				if err != nil || result == nil {
+					return err
+				}
+				simulated, err := uniswap.UnpackSwapAmounts(result)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("simulated out: %s %s\n", utils.ToDecimal(simulated[len(simulated)-1], tknOut.Decimals), tknOut.Symbol)
+				return nil
+			},
+		},
+	},
+}
+
+// executeCall simulates the call and prints its calldata when dry running, returning the
+// result of the simulation, or signs and sends it when the send flag is set
+func executeCall(c *cli.Context, client *bclient.Client, call bclient.Call) ([]byte, error) {
+	if c.Bool("send") && c.Bool("dry-run") {
+		return nil, errors.New("only one of dry-run and send may be set")
+	}
+	key, err := loadTradeKey(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Bool("send") {
+		if key == nil {
+			return nil, errors.New("a keystore or key file is required to send transactions")
+		}
+		tx, err := client.Send(key, call)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("sent transaction: %s\n", tx.Hash())
+		return nil, nil
+	}
+	if call.From, err = tradeSender(c, client); err != nil {
+		return nil, err
+	}
+	value := call.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	fmt.Printf("from: %s\nto: %s\nvalue: %s\ncalldata: %s\n", call.From, call.To, value, hexutil.Encode(call.Data))
+	result, err := client.Simulate(call)
+	if err != nil {
+		return nil, fmt.Errorf("simulation failed: %w", err)
+	}
+	return result, nil
+}
+
+// tradeSender returns the address of the signing key, or the eth.address flag if no key is given.
+// The flag may be an ENS name
+func tradeSender(c *cli.Context, client *bclient.Client) (common.Address, error) {
+	key, err := loadTradeKey(c)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if key != nil {
+		return crypto.PubkeyToAddress(key.PublicKey), nil
+	}
+	address, err := client.ResolveAddress(c.String("eth.address"))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid eth.address: %w", err)
+	}
+	return address, nil
+}
+
+// loadTradeKey loads the signing key from the keystore or key file flags, returning nil if neither is set
+func loadTradeKey(c *cli.Context) (*ecdsa.PrivateKey, error) {
+	switch {
+	case c.String("keystore") != "" && c.String("key.file") != "":
+		return nil, errors.New("only one of keystore and key.file may be set")
+	case c.String("keystore") != "":
+		var password string
+		if c.String("keystore.password_file") != "" {
+			data, err := ioutil.ReadFile(c.String("keystore.password_file"))
+			if err != nil {
+				return nil, err
+			}
+			password = strings.TrimRight(string(data), "\r\n")
+		}
+		return bclient.LoadKeystoreKey(c.String("keystore"), password)
+	case c.String("key.file") != "":
+		return bclient.LoadHexKey(c.String("key.file"))
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/health"
+	"github.com/bonedaddy/unibot/pricing"
+	"github.com/bonedaddy/unibot/watcher"
+)
+
+// updater records the prices of the configured watchers, owning the clients of the networks
+// they are on other than the network of the main client. The chain of each client is tracked
+// to roll back the prices recorded from reorganized blocks
+type updater struct {
+	service  *watcher.Service
+	clients  []*bclient.Client
+	trackers []*watcher.ReorgTracker
+	current  []discord.Watcher
+}
+
+// newUpdater returns an updater for the configured watchers, using bc for the watchers on its network
+func newUpdater(ctx context.Context, cfg *discord.Config, bc *bclient.Client, database *db.Database) (*updater, error) {
+	clients, err := newWatcherClients(cfg, bc)
+	if err != nil {
+		return nil, err
+	}
+	items, err := watcher.ConfigToWatchItmes(cfg, clients...)
+	if err != nil {
+		for _, client := range clients[1:] {
+			client.Close()
+		}
+		return nil, err
+	}
+	conv := pricing.NewConverter(bc, cfg.AnchorAddresses())
+	u := &updater{
+		service: watcher.New(ctx, database, conv, watcher.Opts{Interval: cfg.UpdateInterval, Workers: cfg.UpdateWorkers, StaleAfter: cfg.StaleAfter}, items),
+		clients: clients,
+		current: cfg.Watchers,
+	}
+	confirmations := uint64(cfg.Confirmations)
+	if confirmations == 0 {
+		confirmations = 12
+	}
+	interval := cfg.UpdateInterval
+	if interval <= 0 {
+		interval = time.Second * 5
+	}
+	for _, client := range clients {
+		// the rolled back prices are recorded again from the new chain's head
+		u.trackers = append(u.trackers, watcher.NewReorgTracker(ctx, client, database, confirmations, interval, func(uint64) {
+			u.service.Resync()
+		}))
+	}
+	return u, nil
+}
+
+// Start starts recording prices and tracking reorgs
+func (u *updater) Start() {
+	for _, tracker := range u.trackers {
+		tracker.Start()
+	}
+	u.service.Start()
+}
+
+// Stop stops recording prices and tracking reorgs, and closes the clients of the other networks
+func (u *updater) Stop() {
+	u.service.Stop()
+	for _, tracker := range u.trackers {
+		tracker.Stop()
+	}
+	// the first client is the main client which is owned by the caller
+	for _, client := range u.clients[1:] {
+		client.Close()
+	}
+}
+
+// reload starts and stops watch items to match the watchers of the new config. If any new watcher
+// can't be priced the reload is rejected and the running items are left untouched
+func (u *updater) reload(next *discord.Config) error {
+	added, removed := discord.DiffWatchers(u.current, next.Watchers)
+	changed := append(append([]discord.Watcher{}, added...), removed...)
+	// items are removed by pair, so every item of a changed pair is removed and the pair's watchers
+	// in the new config are started again, as items aren't deduplicated when added
+	var restart []discord.Watcher
+	for _, w := range next.Watchers {
+		for _, c := range changed {
+			if strings.EqualFold(w.Token0Address, c.Token0Address) && strings.EqualFold(w.Token1Address, c.Token1Address) {
+				restart = append(restart, w)
+				break
+			}
+		}
+	}
+	// build the new items first so an unusable watcher rejects the reload as a whole
+	items, err := watcher.ConfigToWatchItmes(&discord.Config{Network: next.Network, Watchers: restart}, u.clients...)
+	if err != nil {
+		return err
+	}
+	for _, w := range changed {
+		u.service.Remove(w.Token0Address, w.Token1Address)
+	}
+	u.service.Add(items...)
+	log.Printf("reloaded watchers: %v added, %v removed, %v restarted\n", len(added), len(removed), len(restart)-len(added))
+	u.current = next.Watchers
+	return nil
+}
+
+// chainUpdater runs the services of chain-updater that write to the database. With leader election
+// they only run while this process leads, so the latest config is kept to start them with
+type chainUpdater struct {
+	ctx      context.Context
+	bc       *bclient.Client
+	database *db.Database
+
+	mux     sync.Mutex
+	cfg     *discord.Config
+	updater *updater
+	arb     *watcher.ArbitrageDetector
+	depth   *watcher.DepthRecorder
+	gas     *watcher.GasTracker
+}
+
+// Start starts recording prices, and the arbitrage detector, depth recorder and gas tracker if enabled
+func (u *chainUpdater) Start() error {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	updater, err := newUpdater(u.ctx, u.cfg, u.bc, u.database)
+	if err != nil {
+		return err
+	}
+	var arb *watcher.ArbitrageDetector
+	if u.cfg.Arbitrage.Enabled {
+		arb, err = watcher.NewArbitrageDetector(u.ctx, u.bc, u.database, u.cfg.Arbitrage, u.cfg.NetworkWatchers(u.bc.Network()), time.Second*5)
+		if err != nil {
+			updater.Stop()
+			return err
+		}
+		arb.Start()
+	}
+	if u.cfg.DepthInterval > 0 {
+		u.depth = watcher.NewDepthRecorder(u.ctx, u.bc, u.database, u.cfg.NetworkWatchers(u.bc.Network()), u.cfg.DepthInterval)
+		u.depth.Start()
+	}
+	if u.cfg.Gas.Interval > 0 {
+		u.gas = watcher.NewGasTracker(u.ctx, u.bc, u.database, u.cfg.Gas.Interval)
+		u.gas.Start()
+	}
+	updater.Start()
+	u.updater, u.arb = updater, arb
+	return nil
+}
+
+// Stop stops the running services
+func (u *chainUpdater) Stop() {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	if u.depth != nil {
+		u.depth.Stop()
+	}
+	if u.gas != nil {
+		u.gas.Stop()
+	}
+	if u.arb != nil {
+		u.arb.Stop()
+	}
+	if u.updater != nil {
+		u.updater.Stop()
+	}
+	u.updater, u.arb, u.depth, u.gas = nil, nil, nil, nil
+}
+
+// Health returns the status of the watch items, which there are none of while standing by
+func (u *chainUpdater) Health(ctx context.Context) []health.Status {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	if u.updater == nil {
+		return nil
+	}
+	return u.updater.service.Health(ctx)
+}
+
+// Reload applies the watchers of the new config to the running updater, and keeps them to start
+// the updater with later
+func (u *chainUpdater) Reload(next *discord.Config) {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	if u.updater != nil {
+		if err := u.updater.reload(next); err != nil {
+			log.Println("rejected config reload: ", err)
+			return
+		}
+	}
+	cfg := *u.cfg
+	cfg.Watchers = next.Watchers
+	u.cfg = &cfg
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/watcher"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdaterReload(t *testing.T) {
+	bc := bclient.NewClientWithBackend(nil)
+	weth, dai := bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String()
+	uniswap := discord.Watcher{Pair: "WETH/DAI", Token0Address: weth, Token1Address: dai}
+	cfg := &discord.Config{Watchers: []discord.Watcher{uniswap}}
+	items, err := watcher.ConfigToWatchItmes(cfg, bc)
+	require.NoError(t, err)
+	u := &updater{service: watcher.New(context.Background(), nil, nil, watcher.Opts{}, items), clients: []*bclient.Client{bc}, current: cfg.Watchers}
+	sources := func() []string {
+		var names []string
+		for _, item := range u.service.Items() {
+			names = append(names, item.Source.Name())
+		}
+		return names
+	}
+
+	// adding a watcher for a watched pair doesn't schedule the existing watcher twice
+	chainlink := uniswap
+	chainlink.Source = "chainlink"
+	chainlink.ChainlinkFeed = "0x773616E4d11A78F511299002da57A0a94577F1f4"
+	next := &discord.Config{Watchers: []discord.Watcher{uniswap, chainlink}}
+	require.NoError(t, u.reload(next))
+	require.ElementsMatch(t, []string{"uniswap", "chainlink"}, sources())
+
+	// removing it leaves the existing watcher
+	require.NoError(t, u.reload(cfg))
+	require.Equal(t, []string{"uniswap"}, sources())
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"gorm.io/gorm"
+)
+
+// Arbitrage is a profitable round trip between two venues trading the same pair.
+// Amounts are denominated in TokenIn, which the round trip starts and ends in
+type Arbitrage struct {
+	gorm.Model
+	ChainID     uint64 `gorm:"index"`
+	BlockNumber uint64
+	BlockHash   string `gorm:"index"`
+	TokenIn     string
+	Token       string
+	BuyVenue    string
+	SellVenue   string
+	AmountIn    float64
+	Profit      float64
+	GasCost     float64
+	NetProfit   float64
+	USDProfit   float64
+	// Final is set once the block is deep enough that it won't be reorganized
+	Final bool
+}
+
+// RecordArbitrage records the given arbitrage opportunity
+func (d *Database) RecordArbitrage(arb *Arbitrage) error {
+	return d.db.Create(arb).Error
+}
+
+// GetArbitrages returns the most recent arbitrage opportunities for the pair, newest first
+func (d *Database) GetArbitrages(tokenIn, token string, limit int) ([]*Arbitrage, error) {
+	var arbs []*Arbitrage
+	return arbs, d.db.Model(&Arbitrage{}).
+		Where("token_in = ? AND token = ?", tokenIn, token).
+		Order("block_number desc").
+		Limit(limit).
+		Find(&arbs).Error
+}
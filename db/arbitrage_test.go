@@ -0,0 +1,47 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArbitrage(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	db := newTestDB(t)
+	arbs := []*Arbitrage{
+		{BlockNumber: 10, TokenIn: "a", Token: "b", BuyVenue: "uniswap", SellVenue: "sushiswap", NetProfit: 1},
+		{BlockNumber: 12, TokenIn: "a", Token: "b", BuyVenue: "sushiswap", SellVenue: "uniswap", NetProfit: 2},
+		{BlockNumber: 11, TokenIn: "a", Token: "b", BuyVenue: "uniswap", SellVenue: "sushiswap", NetProfit: 3},
+		{BlockNumber: 13, TokenIn: "a", Token: "c", BuyVenue: "uniswap", SellVenue: "sushiswap", NetProfit: 4},
+	}
+	for _, arb := range arbs {
+		require.NoError(t, db.RecordArbitrage(arb))
+	}
+	tests := []struct {
+		name       string
+		tokenIn    string
+		token      string
+		limit      int
+		wantBlocks []uint64
+	}{
+		{"A-B", "a", "b", 10, []uint64{12, 11, 10}},
+		{"A-B-limit", "a", "b", 2, []uint64{12, 11}},
+		{"A-C", "a", "c", 10, []uint64{13}},
+		{"B-A", "b", "a", 10, []uint64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := db.GetArbitrages(tt.tokenIn, tt.token, tt.limit)
+			require.NoError(t, err)
+			blocks := make([]uint64, 0, len(got))
+			for _, arb := range got {
+				blocks = append(blocks, arb.BlockNumber)
+			}
+			require.Equal(t, tt.wantBlocks, blocks)
+		})
+	}
+}
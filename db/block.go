@@ -0,0 +1,83 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Block is a recent block of a chain, kept until it is deep enough to be final so that rows
+// recorded from it can be rolled back if it is reorganized out of the chain
+type Block struct {
+	ChainID    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Number     uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Hash       string
+	ParentHash string
+}
+
+// Rollback is the number of rows deleted by a rollback
+type Rollback struct {
+	Prices     int64
+	Swaps      int64
+	Arbitrages int64
+	Pairs      int64
+}
+
+// RecordBlock records the block, replacing the block previously recorded at its height
+func (d *Database) RecordBlock(block *Block) error {
+	return d.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(block).Error
+}
+
+// Blocks returns the recorded blocks of the chain, oldest first
+func (d *Database) Blocks(chainID uint64) ([]Block, error) {
+	var blocks []Block
+	return blocks, d.db.Where("chain_id = ?", chainID).Order("number asc").Find(&blocks).Error
+}
+
+// recorded are the tables of rows recorded from blocks, and the column holding their block number
+var recorded = []struct {
+	model  interface{}
+	column string
+}{
+	{&Price{}, "block"},
+	{&Swap{}, "block_number"},
+	{&Arbitrage{}, "block_number"},
+	{&Pair{}, "first_seen_block"},
+}
+
+// RollbackTo deletes the blocks of the chain after the ancestor along with the prices, swaps,
+// arbitrages and discovered pairs recorded from them. Rows are matched by block number rather than the tracked blocks,
+// as rows may be recorded from a head block before it is tracked, so rows recorded from the
+// canonical blocks after the ancestor are kept by passing their hashes
+func (d *Database) RollbackTo(chainID, ancestor uint64, canonical ...string) (Rollback, error) {
+	var rollback Rollback
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		for i, deleted := range []*int64{&rollback.Prices, &rollback.Swaps, &rollback.Arbitrages, &rollback.Pairs} {
+			table := recorded[i]
+			query := tx.Unscoped().Where("chain_id = ? AND "+table.column+" > ?", chainID, ancestor)
+			if len(canonical) > 0 {
+				query = query.Where("block_hash NOT IN ?", canonical)
+			}
+			// hard deletes so a swap mined again or a pair created again in another block can be recorded again
+			res := query.Delete(table.model)
+			if res.Error != nil {
+				return res.Error
+			}
+			*deleted = res.RowsAffected
+		}
+		return tx.Where("chain_id = ? AND number > ?", chainID, ancestor).Delete(&Block{}).Error
+	})
+	return rollback, err
+}
+
+// Finalize marks the prices, swaps, arbitrages and pairs recorded from blocks of the chain up to and
+// including number as final, and forgets those blocks
+func (d *Database) Finalize(chainID, number uint64) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range recorded {
+			if err := tx.Model(table.model).Where("final = ? AND chain_id = ? AND "+table.column+" <= ?", false, chainID, number).Update("final", true).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("chain_id = ? AND number <= ?", chainID, number).Delete(&Block{}).Error
+	})
+}
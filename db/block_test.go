@@ -0,0 +1,90 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlock(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	db := newTestDB(t)
+	for number, hash := range []string{"0x0", "0x1", "0x2", "0x3"} {
+		require.NoError(t, db.RecordBlock(&Block{ChainID: 1, Number: uint64(number), Hash: hash}))
+	}
+	// another chain with the same hashes isn't affected
+	require.NoError(t, db.RecordBlock(&Block{ChainID: 137, Number: 3, Hash: "0x3"}))
+	for number, hash := range []string{"0x0", "0x1", "0x2", "0x3"} {
+		require.NoError(t, db.RecordObservation(&Price{Token0: "a", Token1: "b", ChainID: 1, Block: uint64(number), BlockHash: hash}))
+		_, err := db.RecordSwap(&Swap{TxHash: "0xa", LogIndex: uint(number), ChainID: 1, BlockNumber: uint64(number), BlockHash: hash, Pair: "ab"})
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.RecordArbitrage(&Arbitrage{ChainID: 1, BlockNumber: 3, BlockHash: "0x3", TokenIn: "a", Token: "b"}))
+	_, err := db.RecordPair(&Pair{Address: "ab", Token0: "a", Token1: "b", ChainID: 1, FirstSeenBlock: 1, BlockHash: "0x1"})
+	require.NoError(t, err)
+	_, err = db.RecordPair(&Pair{Address: "ac", Token0: "a", Token1: "c", ChainID: 1, FirstSeenBlock: 3, BlockHash: "0x3"})
+	require.NoError(t, err)
+	// a price read at a head block that wasn't tracked before it was orphaned
+	require.NoError(t, db.RecordObservation(&Price{Token0: "a", Token1: "b", ChainID: 1, Block: 4, BlockHash: "0x4"}))
+	// a price read at the new chain's block before the reorg was detected
+	require.NoError(t, db.RecordObservation(&Price{Token0: "a", Token1: "b", ChainID: 1, Block: 2, BlockHash: "0x2b"}))
+	require.NoError(t, db.RecordObservation(&Price{Token0: "a", Token1: "b", ChainID: 137, Block: 3, BlockHash: "0x3"}))
+
+	t.Run("RollbackTo", func(t *testing.T) {
+		rollback, err := db.RollbackTo(1, 1, "0x2b", "0x3b")
+		require.NoError(t, err)
+		require.Equal(t, Rollback{Prices: 3, Swaps: 2, Arbitrages: 1, Pairs: 1}, rollback)
+		prices, err := db.GetAllPrices("a", "b")
+		require.NoError(t, err)
+		require.Len(t, prices, 4)
+		require.Equal(t, "0x2b", prices[2].BlockHash)
+		require.Equal(t, uint64(137), prices[3].ChainID)
+		blocks, err := db.Blocks(1)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.Equal(t, "0x1", blocks[1].Hash)
+		blocks, err = db.Blocks(137)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		// the orphaned pair can be recorded and announced again once created in another block
+		isNew, err := db.RecordPair(&Pair{Address: "ac", Token0: "a", Token1: "c", ChainID: 1, FirstSeenBlock: 3, BlockHash: "0x3b"})
+		require.NoError(t, err)
+		require.True(t, isNew)
+		// the orphaned swaps can be recorded again once mined in another block
+		isNew, err = db.RecordSwap(&Swap{TxHash: "0xa", LogIndex: 2, BlockNumber: 3, BlockHash: "0x3b", Pair: "ab"})
+		require.NoError(t, err)
+		require.True(t, isNew)
+	})
+	t.Run("Finalize", func(t *testing.T) {
+		require.NoError(t, db.Finalize(1, 0))
+		prices, err := db.GetAllPrices("a", "b")
+		require.NoError(t, err)
+		require.Len(t, prices, 4)
+		require.True(t, prices[0].Final)
+		require.False(t, prices[1].Final)
+		pairs, err := db.GetPairs("b")
+		require.NoError(t, err)
+		require.False(t, pairs[0].Final)
+		blocks, err := db.Blocks(1)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		// rows are finalized by number, including those of blocks that weren't tracked
+		require.NoError(t, db.Finalize(1, 2))
+		prices, err = db.GetAllPrices("a", "b")
+		require.NoError(t, err)
+		require.True(t, prices[2].Final)
+		require.False(t, prices[3].Final)
+		pairs, err = db.GetPairs("b")
+		require.NoError(t, err)
+		require.True(t, pairs[0].Final)
+	})
+	t.Run("RemoveSwap", func(t *testing.T) {
+		require.NoError(t, db.RemoveSwap("0xa", 2))
+		isNew, err := db.RecordSwap(&Swap{TxHash: "0xa", LogIndex: 2, BlockNumber: 3, BlockHash: "0x3c", Pair: "ab"})
+		require.NoError(t, err)
+		require.True(t, isNew)
+	})
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -67,7 +68,7 @@ func New(opts *Opts) (*Database, error) {
 // AutoMigrate is used to automatically migrate datbase tables
 func (d *Database) AutoMigrate() error {
 	var tables []interface{}
-	tables = append(tables, &Price{})
+	tables = append(tables, &Price{}, &Swap{}, &Pair{}, &Arbitrage{}, &Depth{}, &Lease{}, &Block{}, &GasPrice{})
 	for _, table := range tables {
 		if err := d.db.AutoMigrate(table); err != nil {
 			return err
@@ -76,6 +77,15 @@ func (d *Database) AutoMigrate() error {
 	return nil
 }
 
+// Ping checks the database can be reached
+func (d *Database) Ping(ctx context.Context) error {
+	sdb, err := d.db.DB()
+	if err != nil {
+		return err
+	}
+	return sdb.PingContext(ctx)
+}
+
 // Close shuts down the database
 func (d *Database) Close() error {
 	sdb, err := d.db.DB()
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Depth is the trade needed to move the price of token0 by Move at the time it was recorded.
+// Positive moves buy token0 with token1 and negative moves sell token0 for token1
+type Depth struct {
+	gorm.Model
+	Token0 string
+	Token1 string
+	Move   float64
+	// Amount0 and Amount1 are the amounts of each token exchanged by the trade
+	Amount0 float64
+	Amount1 float64
+}
+
+// RecordDepth records the given depth levels
+func (d *Database) RecordDepth(levels []*Depth) error {
+	return d.db.Create(levels).Error
+}
+
+// GetDepth returns the depth recorded for the pair and price move since the given time, oldest first
+func (d *Database) GetDepth(token0, token1 string, move float64, since time.Time) ([]*Depth, error) {
+	var levels []*Depth
+	return levels, d.db.Model(&Depth{}).
+		Where("token0 = ? AND token1 = ? AND move = ? AND created_at >= ?", token0, token1, move, since).
+		Order("created_at asc").
+		Find(&levels).Error
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepth(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	db := newTestDB(t)
+	start := time.Now().Add(-time.Second)
+	require.NoError(t, db.RecordDepth([]*Depth{
+		{Token0: "a", Token1: "b", Move: 0.01, Amount0: 1, Amount1: 10},
+		{Token0: "a", Token1: "b", Move: -0.01, Amount0: 1, Amount1: 10},
+		{Token0: "a", Token1: "c", Move: 0.01, Amount0: 1, Amount1: 10},
+	}))
+	require.NoError(t, db.RecordDepth([]*Depth{
+		{Token0: "a", Token1: "b", Move: 0.01, Amount0: 2, Amount1: 20},
+	}))
+	tests := []struct {
+		name        string
+		token0      string
+		token1      string
+		move        float64
+		since       time.Time
+		wantAmounts []float64
+	}{
+		{"A-B-up", "a", "b", 0.01, start, []float64{1, 2}},
+		{"A-B-down", "a", "b", -0.01, start, []float64{1}},
+		{"A-C-up", "a", "c", 0.01, start, []float64{1}},
+		{"A-B-future", "a", "b", 0.01, time.Now().Add(time.Hour), []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := db.GetDepth(tt.token0, tt.token1, tt.move, tt.since)
+			require.NoError(t, err)
+			amounts := make([]float64, 0, len(got))
+			for _, level := range got {
+				amounts = append(amounts, level.Amount0)
+			}
+			require.Equal(t, tt.wantAmounts, amounts)
+		})
+	}
+}
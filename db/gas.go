@@ -0,0 +1,32 @@
+package db
+
+import (
+	"gorm.io/gorm"
+)
+
+// GasPrice is a snapshot of the fees suggested for the pending block of a chain, in gwei
+type GasPrice struct {
+	gorm.Model
+	ChainID uint64 `gorm:"index"`
+	// Block is the newest block the fees were sampled from
+	Block   uint64
+	BaseFee float64
+	// SlowTip, StandardTip and FastTip are the suggested priority fees paid on top of the base fee
+	SlowTip     float64
+	StandardTip float64
+	FastTip     float64
+}
+
+// RecordGasPrice records the given gas price snapshot
+func (d *Database) RecordGasPrice(price *GasPrice) error {
+	return d.db.Create(price).Error
+}
+
+// LastGasPrice returns the most recently recorded gas price snapshot of the chain
+func (d *Database) LastGasPrice(chainID uint64) (*GasPrice, error) {
+	var price GasPrice
+	if err := d.db.Model(&GasPrice{}).Where("chain_id = ?", chainID).Last(&price).Error; err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPrice(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	db := newTestDB(t)
+	_, err := db.LastGasPrice(1)
+	require.Error(t, err)
+	tests := []struct {
+		name  string
+		price *GasPrice
+	}{
+		{"mainnet", &GasPrice{ChainID: 1, Block: 10, BaseFee: 30, StandardTip: 2}},
+		{"mainnet-newer", &GasPrice{ChainID: 1, Block: 11, BaseFee: 32, StandardTip: 2}},
+		{"polygon", &GasPrice{ChainID: 137, Block: 500, BaseFee: 40, StandardTip: 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, db.RecordGasPrice(tt.price))
+		})
+	}
+	price, err := db.LastGasPrice(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), price.Block)
+	require.Equal(t, float64(32), price.BaseFee)
+}
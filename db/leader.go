@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Lock is a leadership lock held by at most one process at a time
+type Lock interface {
+	// TryAcquire acquires the lock, or renews it if already held, returning whether it is held
+	TryAcquire(ctx context.Context) (bool, error)
+	// Release gives up the lock if it is held
+	Release(ctx context.Context) error
+}
+
+// Lease is a row granting the holder leadership of the named role until it expires
+type Lease struct {
+	Name   string `gorm:"primaryKey"`
+	Holder string
+	// Expires is the unix time in nanoseconds the lease expires at
+	Expires int64
+}
+
+// LeaderLock returns the leadership lock for the named role. Postgres uses a session advisory lock,
+// which is released as soon as the holder's connection drops. Other databases use a lease row the
+// holder must renew within ttl, so a crashed holder is replaced at most ttl later
+func (d *Database) LeaderLock(name, holder string, ttl time.Duration) (Lock, error) {
+	if d.db.Dialector.Name() == "postgres" {
+		sdb, err := d.db.DB()
+		if err != nil {
+			return nil, err
+		}
+		hash := fnv.New64a()
+		hash.Write([]byte(name))
+		return &advisoryLock{db: sdb, key: int64(hash.Sum64())}, nil
+	}
+	return &leaseLock{db: d.db, name: name, holder: holder, ttl: ttl}, nil
+}
+
+// advisoryLock holds a postgres advisory lock on a dedicated connection
+type advisoryLock struct {
+	db  *sql.DB
+	key int64
+
+	mux  sync.Mutex
+	conn *sql.Conn
+}
+
+func (l *advisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.conn != nil {
+		// the lock is held as long as its connection is alive
+		if err := l.conn.PingContext(ctx); err != nil {
+			l.conn.Close()
+			l.conn = nil
+			return false, err
+		}
+		return true, nil
+	}
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, err
+	}
+	if !acquired {
+		return false, conn.Close()
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *advisoryLock) Release(ctx context.Context) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.conn == nil {
+		return nil
+	}
+	defer func() {
+		l.conn.Close()
+		l.conn = nil
+	}()
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	return err
+}
+
+// leaseLock is held by renewing a lease row before it expires
+type leaseLock struct {
+	db     *gorm.DB
+	name   string
+	holder string
+	ttl    time.Duration
+}
+
+func (l *leaseLock) TryAcquire(ctx context.Context) (bool, error) {
+	now := time.Now()
+	expires := now.Add(l.ttl).UnixNano()
+	// renew the lease if it is ours, or take it over if it expired
+	res := l.db.WithContext(ctx).Model(&Lease{}).
+		Where("name = ? AND (holder = ? OR expires < ?)", l.name, l.holder, now.UnixNano()).
+		Updates(map[string]interface{}{"holder": l.holder, "expires": expires})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+	// otherwise the lease is either held by someone else or doesn't exist yet
+	res = l.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Lease{Name: l.name, Holder: l.holder, Expires: expires})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (l *leaseLock) Release(ctx context.Context) error {
+	return l.db.WithContext(ctx).Model(&Lease{}).
+		Where("name = ? AND holder = ?", l.name, l.holder).
+		Update("expires", 0).Error
+}
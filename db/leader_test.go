@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderLock(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	db := newTestDB(t)
+	ctx := context.Background()
+	a, err := db.LeaderLock("chain-updater", "a", time.Hour)
+	require.NoError(t, err)
+	b, err := db.LeaderLock("chain-updater", "b", time.Hour)
+	require.NoError(t, err)
+	other, err := db.LeaderLock("other", "b", time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		lock     Lock
+		release  bool
+		wantHeld bool
+	}{
+		{"a acquires", a, false, true},
+		{"b waits", b, false, false},
+		{"other role", other, false, true},
+		{"a renews", a, false, true},
+		{"a releases", a, true, false},
+		{"b takes over", b, false, true},
+		{"a waits", a, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.release {
+				require.NoError(t, tt.lock.Release(ctx))
+				return
+			}
+			held, err := tt.lock.TryAcquire(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHeld, held)
+		})
+	}
+
+	t.Run("expired lease", func(t *testing.T) {
+		c, err := db.LeaderLock("expiring", "c", time.Millisecond*50)
+		require.NoError(t, err)
+		d, err := db.LeaderLock("expiring", "d", time.Millisecond*50)
+		require.NoError(t, err)
+		held, err := c.TryAcquire(ctx)
+		require.NoError(t, err)
+		require.True(t, held)
+		held, err = d.TryAcquire(ctx)
+		require.NoError(t, err)
+		require.False(t, held)
+		// c fails to renew in time, so d takes over
+		time.Sleep(time.Millisecond * 100)
+		held, err = d.TryAcquire(ctx)
+		require.NoError(t, err)
+		require.True(t, held)
+		held, err = c.TryAcquire(ctx)
+		require.NoError(t, err)
+		require.False(t, held)
+	})
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Pair is a uniswap pair discovered through the factory
+type Pair struct {
+	gorm.Model
+	Address         string `gorm:"uniqueIndex"`
+	Token0          string
+	Token1          string
+	ChainID         uint64 `gorm:"index"`
+	FirstSeenBlock  uint64
+	BlockHash       string `gorm:"index"`
+	InitialReserve0 float64
+	InitialReserve1 float64
+	// Final is set once the block the pair was created in is deep enough that it won't be reorganized
+	Final bool
+	// Pending is set while the pair hasn't been announced yet
+	Pending bool `gorm:"index"`
+}
+
+// RecordPair records the given pair, returning false if the pair was already recorded
+func (d *Database) RecordPair(pair *Pair) (bool, error) {
+	res := d.db.Clauses(clause.OnConflict{DoNothing: true}).Create(pair)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// GetPairs returns all discovered pairs containing the given token
+func (d *Database) GetPairs(token string) ([]*Pair, error) {
+	var pairs []*Pair
+	return pairs, d.db.Model(&Pair{}).Where("token0 = ? OR token1 = ?", token, token).Find(&pairs).Error
+}
+
+// LastPairBlock returns the block in which the most recently discovered pair was first seen
+func (d *Database) LastPairBlock() (uint64, error) {
+	var pair Pair
+	if err := d.db.Model(&Pair{}).Order("first_seen_block desc").First(&pair).Error; err != nil {
+		return 0, err
+	}
+	return pair.FirstSeenBlock, nil
+}
+
+// PairPending returns whether the pair is still waiting to be announced
+func (d *Database) PairPending(address string) (bool, error) {
+	var pair Pair
+	if err := d.db.Model(&Pair{}).Where("address = ?", address).First(&pair).Error; err != nil {
+		return false, err
+	}
+	return pair.Pending, nil
+}
+
+// MarkPairPosted clears the pending flag of the pair once it has been announced
+func (d *Database) MarkPairPosted(address string) error {
+	return d.db.Model(&Pair{}).Where("address = ?", address).Update("pending", false).Error
+}
+
+// PendingPairBlock returns the block in which the earliest pair waiting to be announced was first seen
+func (d *Database) PendingPairBlock() (uint64, error) {
+	var pair Pair
+	if err := d.db.Model(&Pair{}).Where("pending = ?", true).Order("first_seen_block asc").First(&pair).Error; err != nil {
+		return 0, err
+	}
+	return pair.FirstSeenBlock, nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPair(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	db := newTestDB(t)
+	t.Run("RecordPair", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			pair    *Pair
+			wantNew bool
+		}{
+			{"AB", &Pair{Address: "ab", Token0: "a", Token1: "b", FirstSeenBlock: 10}, true},
+			{"AC", &Pair{Address: "ac", Token0: "a", Token1: "c", FirstSeenBlock: 11}, true},
+			{"AB-duplicate", &Pair{Address: "ab", Token0: "a", Token1: "b", FirstSeenBlock: 12}, false},
+			{"BC", &Pair{Address: "bc", Token0: "b", Token1: "c", FirstSeenBlock: 13}, true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				isNew, err := db.RecordPair(tt.pair)
+				require.NoError(t, err)
+				require.Equal(t, tt.wantNew, isNew)
+			})
+		}
+	})
+	t.Run("GetPairs", func(t *testing.T) {
+		pairs, err := db.GetPairs("a")
+		require.NoError(t, err)
+		require.Len(t, pairs, 2)
+		pairs, err = db.GetPairs("c")
+		require.NoError(t, err)
+		require.Len(t, pairs, 2)
+		pairs, err = db.GetPairs("d")
+		require.NoError(t, err)
+		require.Len(t, pairs, 0)
+	})
+	t.Run("LastPairBlock", func(t *testing.T) {
+		block, err := db.LastPairBlock()
+		require.NoError(t, err)
+		require.Equal(t, uint64(13), block)
+	})
+	t.Run("Pending", func(t *testing.T) {
+		_, err := db.PendingPairBlock()
+		require.Error(t, err)
+		_, err = db.RecordPair(&Pair{Address: "bd", Token0: "b", Token1: "d", FirstSeenBlock: 14, Pending: true})
+		require.NoError(t, err)
+		pending, err := db.PairPending("bd")
+		require.NoError(t, err)
+		require.True(t, pending)
+		block, err := db.PendingPairBlock()
+		require.NoError(t, err)
+		require.Equal(t, uint64(14), block)
+
+		require.NoError(t, db.MarkPairPosted("bd"))
+		pending, err = db.PairPending("bd")
+		require.NoError(t, err)
+		require.False(t, pending)
+		_, err = db.PendingPairBlock()
+		require.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"errors"
 	"math"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,9 +12,35 @@ import (
 // Price is a given price entry for an asset
 type Price struct {
 	gorm.Model
-	Token0   string
-	Token1   string
-	USDPrice float64
+	Token0 string
+	Token1 string
+	// NativePrice is the price of token0 denominated in token1
+	NativePrice float64
+	ETHPrice    float64
+	DAIPrice    float64
+	USDPrice    float64
+	// ChainID is the chain the price was read from, and Block the block it was read at, 0 if unknown
+	ChainID   uint64 `gorm:"index"`
+	Block     uint64
+	BlockHash string `gorm:"index"`
+	// Final is set once the block is deep enough that it won't be reorganized
+	Final bool
+}
+
+// In returns the price in the given currency, one of native, eth, dai or usd
+func (p *Price) In(currency string) (float64, error) {
+	switch strings.ToLower(currency) {
+	case "native":
+		return p.NativePrice, nil
+	case "eth":
+		return p.ETHPrice, nil
+	case "dai":
+		return p.DAIPrice, nil
+	case "usd":
+		return p.USDPrice, nil
+	default:
+		return 0, errors.New("unsupported currency")
+	}
 }
 
 // RecordPrice records the given asset price in the database
@@ -21,6 +48,29 @@ func (d *Database) RecordPrice(token0 string, token1 string, price float64) erro
 	return d.db.Create(&Price{Token0: token0, Token1: token1, USDPrice: price}).Error
 }
 
+// RecordObservation records a price observation containing the price in every currency
+func (d *Database) RecordObservation(price *Price) error {
+	return d.db.Create(price).Error
+}
+
+// LastObservation returns the last recorded price observation
+func (d *Database) LastObservation(token0, token1 string) (*Price, error) {
+	var price Price
+	if err := d.db.Model(&Price{}).Where("token0 = ? AND token1 = ?", token0, token1).Last(&price).Error; err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+// LastPriceIn returns the last recorded price in the given currency
+func (d *Database) LastPriceIn(token0, token1, currency string) (float64, error) {
+	price, err := d.LastObservation(token0, token1)
+	if err != nil {
+		return 0, err
+	}
+	return price.In(currency)
+}
+
 // LastPrice returns the last recorded price
 func (d *Database) LastPrice(token0, token1 string) (float64, error) {
 	var price Price
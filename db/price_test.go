@@ -196,4 +196,35 @@ func TestPrice(t *testing.T) {
 			})
 		}
 	})
+	t.Run("LastPriceIn", func(t *testing.T) {
+		require.NoError(t, db.RecordObservation(&Price{
+			Token0: "g", Token1: "h", NativePrice: 0.5, ETHPrice: 0.25, DAIPrice: 301, USDPrice: 300,
+		}))
+		tests := []struct {
+			currency string
+			want     float64
+			wantErr  bool
+		}{
+			{"native", 0.5, false},
+			{"ETH", 0.25, false},
+			{"dai", 301, false},
+			{"usd", 300, false},
+			{"eur", 0, true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.currency, func(t *testing.T) {
+				price, err := db.LastPriceIn("g", "h", tt.currency)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("LastPriceIn() err %v, wantErr %v", err, tt.wantErr)
+				}
+				require.Equal(t, tt.want, price)
+			})
+		}
+		observation, err := db.LastObservation("g", "h")
+		require.NoError(t, err)
+		require.Equal(t, 0.5, observation.NativePrice)
+		require.Equal(t, 300.0, observation.USDPrice)
+		_, err = db.LastObservation("h", "g")
+		require.Error(t, err)
+	})
 }
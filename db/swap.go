@@ -0,0 +1,76 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Swap is a swap observed on a uniswap pair
+type Swap struct {
+	gorm.Model
+	TxHash      string `gorm:"uniqueIndex:idx_swap_tx_log"`
+	LogIndex    uint   `gorm:"uniqueIndex:idx_swap_tx_log"`
+	ChainID     uint64 `gorm:"index"`
+	BlockNumber uint64
+	BlockHash   string `gorm:"index"`
+	Pair        string
+	Token0      string
+	Token1      string
+	Trader      string
+	Buy         bool
+	Amount0     float64
+	Amount1     float64
+	USDValue    float64
+	// Final is set once the block is deep enough that it won't be reorganized
+	Final bool
+	// Pending is set while a swap worth posting hasn't been posted yet
+	Pending bool `gorm:"index"`
+}
+
+// RecordSwap records the given swap, returning false if the swap was already recorded.
+// Swaps are identified by their transaction hash and log index so that swaps
+// seen again after resubscribing to events are not recorded twice
+func (d *Database) RecordSwap(swap *Swap) (bool, error) {
+	res := d.db.Clauses(clause.OnConflict{DoNothing: true}).Create(swap)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// LastSwapBlock returns the block number of the most recently recorded swap on the pair
+func (d *Database) LastSwapBlock(pair string) (uint64, error) {
+	var swap Swap
+	if err := d.db.Model(&Swap{}).Where("pair = ?", pair).Order("block_number desc").First(&swap).Error; err != nil {
+		return 0, err
+	}
+	return swap.BlockNumber, nil
+}
+
+// RemoveSwap deletes the swap, which is used when its log is removed by a reorg
+func (d *Database) RemoveSwap(txHash string, logIndex uint) error {
+	return d.db.Unscoped().Where("tx_hash = ? AND log_index = ?", txHash, logIndex).Delete(&Swap{}).Error
+}
+
+// SwapPending returns whether the swap is still waiting to be posted
+func (d *Database) SwapPending(txHash string, logIndex uint) (bool, error) {
+	var swap Swap
+	if err := d.db.Model(&Swap{}).Where("tx_hash = ? AND log_index = ?", txHash, logIndex).First(&swap).Error; err != nil {
+		return false, err
+	}
+	return swap.Pending, nil
+}
+
+// MarkSwapPosted clears the pending flag of the swap once it has been posted
+func (d *Database) MarkSwapPosted(txHash string, logIndex uint) error {
+	return d.db.Model(&Swap{}).Where("tx_hash = ? AND log_index = ?", txHash, logIndex).Update("pending", false).Error
+}
+
+// PendingSwapBlock returns the block number of the earliest swap on the pair waiting to be posted
+func (d *Database) PendingSwapBlock(pair string) (uint64, error) {
+	var swap Swap
+	if err := d.db.Model(&Swap{}).Where("pair = ? AND pending = ?", pair, true).Order("block_number asc").First(&swap).Error; err != nil {
+		return 0, err
+	}
+	return swap.BlockNumber, nil
+}
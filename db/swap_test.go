@@ -0,0 +1,60 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwap(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	db := newTestDB(t)
+	t.Run("RecordSwap", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			swap    *Swap
+			wantNew bool
+		}{
+			{"new", &Swap{TxHash: "0xa", LogIndex: 1, BlockNumber: 10, Pair: "ab"}, true},
+			{"new-log-index", &Swap{TxHash: "0xa", LogIndex: 2, BlockNumber: 10, Pair: "ab"}, true},
+			{"duplicate", &Swap{TxHash: "0xa", LogIndex: 1, BlockNumber: 10, Pair: "ab"}, false},
+			{"new-tx", &Swap{TxHash: "0xb", LogIndex: 1, BlockNumber: 12, Pair: "ab"}, true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				isNew, err := db.RecordSwap(tt.swap)
+				require.NoError(t, err)
+				require.Equal(t, tt.wantNew, isNew)
+			})
+		}
+	})
+	t.Run("LastSwapBlock", func(t *testing.T) {
+		block, err := db.LastSwapBlock("ab")
+		require.NoError(t, err)
+		require.Equal(t, uint64(12), block)
+		_, err = db.LastSwapBlock("cd")
+		require.Error(t, err)
+	})
+	t.Run("Pending", func(t *testing.T) {
+		_, err := db.PendingSwapBlock("ab")
+		require.Error(t, err)
+		_, err = db.RecordSwap(&Swap{TxHash: "0xc", LogIndex: 1, BlockNumber: 11, Pair: "ab", Pending: true})
+		require.NoError(t, err)
+		pending, err := db.SwapPending("0xc", 1)
+		require.NoError(t, err)
+		require.True(t, pending)
+		block, err := db.PendingSwapBlock("ab")
+		require.NoError(t, err)
+		require.Equal(t, uint64(11), block)
+
+		require.NoError(t, db.MarkSwapPosted("0xc", 1))
+		pending, err = db.SwapPending("0xc", 1)
+		require.NoError(t, err)
+		require.False(t, pending)
+		_, err = db.PendingSwapBlock("ab")
+		require.Error(t, err)
+	})
+}
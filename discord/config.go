@@ -3,19 +3,50 @@ package discord
 import (
 	"io/ioutil"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/network"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/ethereum/go-ethereum/common"
 	"gopkg.in/yaml.v2"
 )
 
 // Config bundles together discord configuration information
 type Config struct {
 	// if nil we dont use infura and connect directly to the rpc node below
-	InfuraAPIKey    string    `yaml:"infura_api_key"`
-	InfuraWSEnabled bool      `yaml:"infura_ws_enabled"`
-	ETHRPCEndpoint  string    `yaml:"eth_rpc_endpoint"`
-	Watchers        []Watcher `yaml:"watchers"`
-	Database        Database  `yaml:"database"`
+	InfuraAPIKey    string `yaml:"infura_api_key"`
+	InfuraWSEnabled bool   `yaml:"infura_ws_enabled"`
+	ETHRPCEndpoint  string `yaml:"eth_rpc_endpoint"`
+	// Network is the name or chain id of the network the endpoint above is on, defaults to mainnet
+	Network string `yaml:"network"`
+	// Networks configures the endpoints of the other networks watchers are on. Networks without an
+	// endpoint use infura if an api key is set, falling back to the network's public endpoint
+	Networks []NetworkEndpoint `yaml:"networks"`
+	// token for the bot serving !ndx commands, if empty no commands are served
+	DiscordToken string    `yaml:"discord_token"`
+	Watchers     []Watcher `yaml:"watchers"`
+	// stablecoins used to derive USD prices, defaults to DAI, USDC and USDT
+	USDAnchors []string `yaml:"usd_anchors"`
+	Database   Database `yaml:"database"`
+	// UpdateInterval is how often watchers without their own interval are priced, defaults to 5s
+	UpdateInterval time.Duration `yaml:"update_interval"`
+	// UpdateWorkers is how many watchers are priced concurrently, defaults to 4
+	UpdateWorkers int `yaml:"update_workers"`
+	// StaleAfter is how long a watcher can go without a price update before its bot shows the price
+	// is stale and the health checks fail, defaults to 5m
+	StaleAfter time.Duration `yaml:"stale_after"`
+	// Confirmations is how many blocks deep recorded prices, swaps, arbitrages and discovered pairs
+	// must be before they are final, until then they are rolled back if their block is reorganized
+	// out of the chain. Defaults to 12
+	Confirmations int `yaml:"confirmations"`
+	// DepthInterval is how often the liquidity depth of watched pairs is recorded, disabled if 0
+	DepthInterval time.Duration `yaml:"depth_interval"`
+	WhaleWatch    WhaleWatch    `yaml:"whale_watch"`
+	Discovery     Discovery     `yaml:"discovery"`
+	Arbitrage     Arbitrage     `yaml:"arbitrage"`
+	Gas           Gas           `yaml:"gas"`
 }
 
 // Database provides configuration over our database connection
@@ -30,38 +61,225 @@ type Database struct {
 	SSLModeDisable bool   `yaml:"ssl_mode_disable"`
 }
 
+// NetworkEndpoint is the RPC endpoint used to connect to a network
+type NetworkEndpoint struct {
+	// Name is the name or chain id of the network
+	Name string `yaml:"name"`
+	RPC  string `yaml:"rpc"`
+}
+
 // Watcher is used to start a process that watches the price of a token
 // and posts its value as a name
 type Watcher struct {
-	DiscordToken  string `yaml:"discord_token"`
+	DiscordToken string `yaml:"discord_token"`
+	// Token0Address and Token1Address are hex addresses, or ENS names when the watcher is on the
+	// config's network. Like every address in the config names are resolved on startup
 	Token0Address string `yaml:"token0_address"`
 	Token1Address string `yaml:"token1_address"`
 	Pair          string `yaml:"pair"`
-	Decimals      int    `yaml:"decimals"`
+	// Decimals is no longer used as prices are adjusted for the decimals of the tokens, it is
+	// kept so configs setting it still load, and is checked against the quote token if set
+	Decimals int `yaml:"decimals"`
+	// Source selects where prices come from, one of uniswap (default), uniswap-v3, chainlink or median
+	Source string `yaml:"source"`
+	// FeeTier selects the pool used by the uniswap-v3 source, defaults to 3000 (0.3%)
+	FeeTier uint32 `yaml:"fee_tier"`
+	// ChainlinkFeed is the aggregator used by the chainlink and median sources
+	ChainlinkFeed string `yaml:"chainlink_feed"`
+	// MaxDeviation is the fraction the median source allows prices to deviate from the median
+	MaxDeviation float64 `yaml:"max_deviation"`
+	// Network is the name or chain id of the network the tokens are on, defaults to the config's network
+	Network string `yaml:"network"`
+	// Interval is how often the price is updated, defaults to the config's update interval
+	Interval time.Duration `yaml:"interval"`
+}
+
+// WhaleWatch is used to post swaps above a USD threshold to discord channels.
+// Watching swaps requires a websockets connection to the ethereum node
+type WhaleWatch struct {
+	Enabled      bool   `yaml:"enabled"`
+	DiscordToken string `yaml:"discord_token"`
+	// ChannelID is the channel swaps are posted to unless overridden by a pair
+	ChannelID   string      `yaml:"channel_id"`
+	ExplorerURL string      `yaml:"explorer_url"`
+	Pairs       []WhalePair `yaml:"pairs"`
+}
+
+// WhalePair configures the threshold and routing of whale swaps for a single pair
+type WhalePair struct {
+	Token0Address string  `yaml:"token0_address"`
+	Token1Address string  `yaml:"token1_address"`
+	ThresholdUSD  float64 `yaml:"threshold_usd"`
+	ChannelID     string  `yaml:"channel_id"`
+}
+
+// Discovery is used to post newly created pairs involving any of the configured tokens.
+// Watching the factory requires a websockets connection to the ethereum node
+type Discovery struct {
+	Enabled      bool     `yaml:"enabled"`
+	DiscordToken string   `yaml:"discord_token"`
+	ChannelID    string   `yaml:"channel_id"`
+	ExplorerURL  string   `yaml:"explorer_url"`
+	Tokens       []string `yaml:"tokens"`
+}
+
+// Gas is used to record the gas fees suggested for the pending block, and show them on a bot
+type Gas struct {
+	// Interval is how often the fees are recorded by chain-updater and shown on the bot, recording
+	// is disabled if 0 and the bot's fees are refreshed every minute
+	Interval time.Duration `yaml:"interval"`
+	// DiscordToken is the token of a bot showing the standard gas price as its nickname, disabled if empty
+	DiscordToken string `yaml:"discord_token"`
+}
+
+// Arbitrage is used to compare the price of every watched pair across uniswap forks.
+// Round trips start and end in each watcher's token1, and are only ever logged, never executed
+type Arbitrage struct {
+	Enabled bool `yaml:"enabled"`
+	// Venues are the names of the venues on the network to compare, defaults to all of them
+	Venues []string `yaml:"venues"`
+	// GasLimit is the gas used to estimate the cost of a round trip, defaults to 300000
+	GasLimit uint64 `yaml:"gas_limit"`
+	// MinProfitUSD is the net profit an opportunity needs before it is posted to discord
+	MinProfitUSD float64 `yaml:"min_profit_usd"`
+	// if DiscordToken is empty opportunities are only recorded in the database
+	DiscordToken string `yaml:"discord_token"`
+	ChannelID    string `yaml:"channel_id"`
 }
 
 var (
 	// ExampleConfig is primarily used to provide a template for generating the config file
 	ExampleConfig = &Config{
-		InfuraAPIKey:    "INFURA-KEY",
+		InfuraAPIKey:    envPlaceholder("infura_api_key"),
 		InfuraWSEnabled: false,
 		ETHRPCEndpoint:  "http://localhost:8545",
+		Network:         network.Mainnet.Name,
+		Networks: []NetworkEndpoint{
+			{Name: network.Polygon.Name, RPC: network.Polygon.RPCURL},
+		},
+		DiscordToken: envPlaceholder("discord_token"),
+		USDAnchors: []string{
+			bclient.DAITokenAddress.String(),
+			bclient.USDCTokenAddress.String(),
+			bclient.USDTTokenAddress.String(),
+		},
 		Watchers: []Watcher{
-			{DiscordToken: "CHANGEME-TOKEN", Token0Address: bclient.WETHTokenAddress.String(), Token1Address: bclient.DAITokenAddress.String()},
+			{DiscordToken: envPlaceholder("watchers[0].discord_token"), Token0Address: bclient.WETHTokenAddress.String(), Token1Address: bclient.DAITokenAddress.String(), Pair: "WETH/DAI"},
 		},
+		UpdateInterval: time.Second * 5,
+		UpdateWorkers:  4,
+		StaleAfter:     time.Minute * 5,
+		Confirmations:  12,
+		DepthInterval:  time.Minute * 5,
 		Database: Database{
 			Type:           "sqlite",
 			Host:           "localhost",
 			Port:           "5432",
 			User:           "user",
-			Pass:           "pass",
+			Pass:           envPlaceholder("database.pass"),
 			DBName:         "indexed",
 			DBPath:         "/changeme",
 			SSLModeDisable: false,
 		},
+		WhaleWatch: WhaleWatch{
+			Enabled:      false,
+			DiscordToken: envPlaceholder("whale_watch.discord_token"),
+			ChannelID:    "CHANGEME-CHANNEL",
+			ExplorerURL:  "https://etherscan.io",
+			Pairs: []WhalePair{
+				{Token0Address: bclient.DEFI5TokenAddress.String(), Token1Address: bclient.WETHTokenAddress.String(), ThresholdUSD: 10000},
+			},
+		},
+		Discovery: Discovery{
+			Enabled:      false,
+			DiscordToken: envPlaceholder("discovery.discord_token"),
+			ChannelID:    "CHANGEME-CHANNEL",
+			ExplorerURL:  "https://etherscan.io",
+			Tokens: []string{
+				bclient.DEFI5TokenAddress.String(),
+				bclient.CC10TokenAddress.String(),
+				bclient.NDXTokenAddress.String(),
+			},
+		},
+		Arbitrage: Arbitrage{
+			Enabled:      false,
+			Venues:       []string{"uniswap", "sushiswap"},
+			GasLimit:     300000,
+			MinProfitUSD: 100,
+			DiscordToken: envPlaceholder("arbitrage.discord_token"),
+			ChannelID:    "CHANGEME-CHANNEL",
+		},
+		Gas: Gas{
+			Interval: time.Minute,
+		},
 	}
 )
 
+// AnchorAddresses returns the configured USD anchors as addresses
+func (c *Config) AnchorAddresses() []common.Address {
+	anchors := make([]common.Address, 0, len(c.USDAnchors))
+	for _, anchor := range c.USDAnchors {
+		anchors = append(anchors, common.HexToAddress(anchor))
+	}
+	return anchors
+}
+
+// WatcherNetwork returns the network the watcher's tokens are on
+func (c *Config) WatcherNetwork(watcher Watcher) (*network.Network, error) {
+	if watcher.Network == "" {
+		return network.Lookup(c.Network)
+	}
+	return network.Lookup(watcher.Network)
+}
+
+// NetworkWatchers returns the watchers whose tokens are on the given network
+func (c *Config) NetworkWatchers(net *network.Network) []Watcher {
+	var watchers []Watcher
+	for _, watcher := range c.Watchers {
+		if n, err := c.WatcherNetwork(watcher); err == nil && n.ChainID == net.ChainID {
+			watchers = append(watchers, watcher)
+		}
+	}
+	return watchers
+}
+
+// PortfolioAssets returns the tokens and uniswap pairs on the network whose balances portfolios
+// list: the network's wrapped native currency and stablecoins, the tokens of its watchers, and on
+// mainnet the index tokens and the tokens pair discovery looks for
+func (c *Config) PortfolioAssets(net *network.Network) ([]common.Address, []uniswap.Pair) {
+	tokens := append([]common.Address{net.WrappedNative}, net.Stablecoins...)
+	if net.ChainID == network.Mainnet.ChainID {
+		tokens = append(tokens, bclient.DEFI5TokenAddress, bclient.CC10TokenAddress, bclient.NDXTokenAddress)
+		for _, token := range c.Discovery.Tokens {
+			tokens = append(tokens, common.HexToAddress(token))
+		}
+	}
+	var pairs []uniswap.Pair
+	for _, watcher := range c.NetworkWatchers(net) {
+		token0, token1 := common.HexToAddress(watcher.Token0Address), common.HexToAddress(watcher.Token1Address)
+		tokens = append(tokens, token0, token1)
+		// only uniswap v2 style pairs have LP tokens
+		switch strings.ToLower(watcher.Source) {
+		case "", "uniswap", "median":
+			pairs = append(pairs, uniswap.Pair{Token0: token0, Token1: token1})
+		}
+	}
+	return tokens, pairs
+}
+
+// NetworkRPC returns the RPC endpoint configured for the network, if any
+func (c *Config) NetworkRPC(net *network.Network) string {
+	if n, err := network.Lookup(c.Network); err == nil && n.ChainID == net.ChainID {
+		return c.ETHRPCEndpoint
+	}
+	for _, endpoint := range c.Networks {
+		if n, err := network.Lookup(endpoint.Name); err == nil && n.ChainID == net.ChainID {
+			return endpoint.RPC
+		}
+	}
+	return ""
+}
+
 // NewConfig generates a new config and stores at path
 func NewConfig(path string) error {
 	data, err := yaml.Marshal(ExampleConfig)
@@ -71,15 +289,27 @@ func NewConfig(path string) error {
 	return ioutil.WriteFile(path, data, os.ModePerm)
 }
 
-// LoadConfig loads the configuration
+// LoadConfig loads the configuration, layering the environment over it as described by ApplyEnv
 func LoadConfig(path string) (*Config, error) {
 	r, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 	var cfg Config
-	if err := yaml.Unmarshal(r, &cfg); err != nil {
+	// unknown fields are rejected so typos don't silently fall back to defaults
+	if err := yaml.UnmarshalStrict(r, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.ApplyEnv(); err != nil {
 		return nil, err
 	}
 	return &cfg, nil
 }
+
+// staleAfter returns how long a watcher can go without a price update before it is stale
+func (c *Config) staleAfter() time.Duration {
+	if c.StaleAfter > 0 {
+		return c.StaleAfter
+	}
+	return time.Minute * 5
+}
@@ -3,18 +3,48 @@ package discord
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/network"
 	"github.com/stretchr/testify/require"
 )
 
 func TestConfig(t *testing.T) {
 	t.Cleanup(func() {
 		os.Remove("test-config.yml")
+		os.Unsetenv("UNIBOT_WATCHERS_0_DISCORD_TOKEN")
 	})
 	err := NewConfig("test-config.yml")
 	require.NoError(t, err)
+	// secrets reference environment variables
+	os.Setenv("UNIBOT_WATCHERS_0_DISCORD_TOKEN", "CHANGEME-TOKEN")
 	cfg, err := LoadConfig("test-config.yml")
 	require.NoError(t, err)
 	require.Len(t, cfg.Watchers, 1)
 	require.Equal(t, cfg.Watchers[0].DiscordToken, "CHANGEME-TOKEN")
+	require.Len(t, cfg.WhaleWatch.Pairs, 1)
+	require.Len(t, cfg.Discovery.Tokens, 3)
+	require.Equal(t, time.Minute*5, cfg.DepthInterval)
+
+	// watchers default to the config's network
+	require.Len(t, cfg.NetworkWatchers(network.Mainnet), 1)
+	require.Len(t, cfg.NetworkWatchers(network.Polygon), 0)
+	cfg.Watchers = append(cfg.Watchers, Watcher{Pair: "WMATIC/USDC", Network: "137"})
+	require.Len(t, cfg.NetworkWatchers(network.Polygon), 1)
+	net, err := cfg.WatcherNetwork(cfg.Watchers[1])
+	require.NoError(t, err)
+	require.Equal(t, network.Polygon, net)
+	require.Equal(t, cfg.ETHRPCEndpoint, cfg.NetworkRPC(network.Mainnet))
+	require.Equal(t, network.Polygon.RPCURL, cfg.NetworkRPC(network.Polygon))
+	require.Empty(t, cfg.NetworkRPC(network.BSC))
+
+	// portfolios list the network's tokens and watched pairs
+	tokens, pairs := cfg.PortfolioAssets(network.Mainnet)
+	require.Contains(t, tokens, network.Mainnet.WrappedNative)
+	require.Contains(t, tokens, bclient.NDXTokenAddress)
+	require.Len(t, pairs, 1)
+	tokens, pairs = cfg.PortfolioAssets(network.Polygon)
+	require.NotContains(t, tokens, bclient.NDXTokenAddress)
+	require.Len(t, pairs, 1)
 }
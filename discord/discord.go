@@ -4,9 +4,12 @@ import (
 	"context"
 	"log"
 	"sync"
+	"time"
 
+	"github.com/bonedaddy/dgc"
 	"github.com/bonedaddy/unibot/bclient"
 	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/pricing"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -16,9 +19,28 @@ var (
 
 // Client wraps bclient and discordgo to provide a discord bot for indexed finance
 type Client struct {
-	s  *discordgo.Session
-	bc *bclient.Client
-	db *db.Database
+	s    *discordgo.Session
+	bc   *bclient.Client
+	cfg  *Config
+	conv *pricing.Converter
+	db   *db.Database
+	ww   *WhaleWatcher
+	ds   *DiscoveryService
+
+	// wmux guards the config's watchers, the sessions of their bots and the prices they show
+	wmux     sync.Mutex
+	sessions map[Watcher]*discordgo.Session
+	prices   map[Watcher]*db.Price
+	shown    map[Watcher]string
+
+	// router serves the prefix commands, and the help listing them is also sent for slash commands
+	router *dgc.Router
+	// lmux guards when each user last ran each slash command
+	lmux   sync.Mutex
+	limits map[string]time.Time
+	// registered registers the slash commands on the first ready event only, as the session sends
+	// one each time it reconnects
+	registered sync.Once
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -26,27 +48,80 @@ type Client struct {
 }
 
 // NewClient provides a wrapper around discordgo
-func NewClient(ctx context.Context, cfg *Config, bc *bclient.Client, db *db.Database) (*Client, error) {
+func NewClient(ctx context.Context, cfg *Config, bc *bclient.Client, database *db.Database) (*Client, error) {
 	wg := &sync.WaitGroup{}
+	client := &Client{
+		bc:       bc,
+		cfg:      cfg,
+		conv:     pricing.NewConverter(bc, cfg.AnchorAddresses()),
+		wg:       wg,
+		db:       database,
+		sessions: make(map[Watcher]*discordgo.Session),
+		prices:   make(map[Watcher]*db.Price),
+		shown:    make(map[Watcher]string),
+		limits:   make(map[string]time.Time),
+	}
+	client.ctx, client.cancel = context.WithCancel(ctx)
+	client.startWatchers(cfg.Watchers)
+	if cfg.Gas.DiscordToken != "" {
+		interval := cfg.Gas.Interval
+		if interval <= 0 {
+			interval = time.Minute
+		}
+		client.startGasBot(interval)
+	}
 
-	for _, watcher := range cfg.Watchers {
-		wg.Add(1)
-		go func(discToken, token0, token1 string) {
-			defer wg.Done()
-			dg, err := discordgo.New("Bot " + discToken)
-			if err != nil {
-				log.Println("failed to start watcher: ", err)
-				return
-			}
+	if cfg.DiscordToken != "" {
+		dg, err := discordgo.New("Bot " + cfg.DiscordToken)
+		if err != nil {
+			return nil, err
+		}
+		router := dgc.Create(&dgc.Router{
+			Prefixes:         []string{"!ndx"},
+			IgnorePrefixCase: true,
+			BotsAllowed:      false,
+			Commands:         []*dgc.Command{},
+			Middlewares:      []dgc.Middleware{},
+		})
+		rateLimiter := dgc.NewRateLimiter(time.Minute, time.Minute, func(ctx *dgc.Ctx) {
+			ctx.RespondText(rateLimitMsg)
+		})
+		registerHelpCommand(nil, router)
+		client.registerCommands(router, rateLimiter)
+		router.Initialize(dg)
+		client.router = router
+		// slash commands are registered once the bot's application id is known
+		dg.AddHandler(func(s *discordgo.Session, ready *discordgo.Ready) {
+			client.registered.Do(func() {
+				if err := registerSlashCommands(s, ready.User.ID, client.slashCommands()); err != nil {
+					log.Println("failed to register slash commands: ", err)
+				}
+			})
+		})
+		dg.AddHandler(client.handleEvent)
+		if err := dg.Open(); err != nil {
+			return nil, err
+		}
+		client.s = dg
+	}
 
-			if err := dg.Open(); err != nil {
-				log.Println("failed to start watcher: ", err)
-				return
-			}
-		}(watcher.DiscordToken, watcher.Token0Address, watcher.Token1Address)
+	if cfg.WhaleWatch.Enabled {
+		ww, err := NewWhaleWatcher(ctx, cfg.WhaleWatch, bc, database)
+		if err != nil {
+			return nil, err
+		}
+		ww.Start()
+		client.ww = ww
 	}
 
-	client := &Client{bc: bc, wg: wg, db: db}
+	if cfg.Discovery.Enabled {
+		ds, err := NewDiscoveryService(ctx, cfg.Discovery, bc, database)
+		if err != nil {
+			return nil, err
+		}
+		ds.Start()
+		client.ds = ds
+	}
 
 	log.Println("bot is now running")
 	return client, nil
@@ -54,6 +129,96 @@ func NewClient(ctx context.Context, cfg *Config, bc *bclient.Client, db *db.Data
 
 // Close terminates the discordgo session
 func (c *Client) Close() error {
+	c.cancel()
+	if c.ww != nil {
+		if err := c.ww.Close(); err != nil {
+			log.Println("failed to close whale watcher: ", err)
+		}
+	}
+	if c.ds != nil {
+		if err := c.ds.Close(); err != nil {
+			log.Println("failed to close discovery service: ", err)
+		}
+	}
 	c.wg.Wait()
+	c.wmux.Lock()
+	c.stopWatchers(c.cfg.Watchers)
+	c.wmux.Unlock()
+	if c.s == nil {
+		return nil
+	}
 	return c.s.Close()
 }
+
+// UpdateWatchers replaces the configured watchers, stopping the bots of watchers
+// that were removed and starting bots for watchers that were added
+func (c *Client) UpdateWatchers(watchers []Watcher) {
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	added, removed := DiffWatchers(c.cfg.Watchers, watchers)
+	c.stopWatchers(removed)
+	c.startWatchers(added)
+	// the config is copied so handlers using the previous watchers aren't affected
+	cfg := *c.cfg
+	cfg.Watchers = watchers
+	c.cfg = &cfg
+}
+
+// watchers returns the configured watchers
+func (c *Client) watchers() []Watcher {
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	return c.cfg.Watchers
+}
+
+// startWatchers opens the discord sessions of the watcher bots in the background
+func (c *Client) startWatchers(watchers []Watcher) {
+	for _, watcher := range watchers {
+		c.wg.Add(1)
+		go func(watcher Watcher) {
+			defer c.wg.Done()
+			dg, err := discordgo.New("Bot " + watcher.DiscordToken)
+			if err != nil {
+				log.Println("failed to start watcher: ", err)
+				return
+			}
+			if err := dg.Open(); err != nil {
+				log.Println("failed to start watcher: ", err)
+				return
+			}
+			if c.addSession(watcher, dg) {
+				log.Printf("started watcher bot for pair %s\n", watcher.Pair)
+			}
+		}(watcher)
+	}
+}
+
+// addSession records the opened session of the watcher's bot, closing it instead if the watcher
+// was removed from the config while the session was opening
+func (c *Client) addSession(watcher Watcher, dg *discordgo.Session) bool {
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	if added, _ := DiffWatchers(c.cfg.Watchers, []Watcher{watcher}); len(added) > 0 {
+		dg.Close()
+		return false
+	}
+	c.sessions[watcher] = dg
+	return true
+}
+
+// stopWatchers closes the discord sessions of the watcher bots. wmux must be held
+func (c *Client) stopWatchers(watchers []Watcher) {
+	for _, watcher := range watchers {
+		dg, ok := c.sessions[watcher]
+		if !ok {
+			continue
+		}
+		if err := dg.Close(); err != nil {
+			log.Println("failed to stop watcher: ", err)
+		}
+		delete(c.sessions, watcher)
+		delete(c.prices, watcher)
+		delete(c.shown, watcher)
+		log.Printf("stopped watcher bot for pair %s\n", watcher.Pair)
+	}
+}
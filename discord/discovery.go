@@ -0,0 +1,224 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// DiscoveryService watches the uniswap factory for new pairs involving
+// any of the configured tokens, recording them and posting them to discord
+type DiscoveryService struct {
+	s      *discordgo.Session
+	bc     *bclient.Client
+	db     *db.Database
+	cfg    Discovery
+	tokens map[common.Address]bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// NewDiscoveryService opens the discord session used to post new pairs
+func NewDiscoveryService(ctx context.Context, cfg Discovery, bc *bclient.Client, db *db.Database) (*DiscoveryService, error) {
+	dg, err := discordgo.New("Bot " + cfg.DiscordToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := dg.Open(); err != nil {
+		return nil, err
+	}
+	if cfg.ExplorerURL == "" {
+		cfg.ExplorerURL = "https://etherscan.io"
+	}
+	tokens := make(map[common.Address]bool, len(cfg.Tokens))
+	for _, token := range cfg.Tokens {
+		tokens[common.HexToAddress(token)] = true
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &DiscoveryService{
+		s:      dg,
+		bc:     bc,
+		db:     db,
+		cfg:    cfg,
+		tokens: tokens,
+		ctx:    ctx,
+		cancel: cancel,
+		wg:     &sync.WaitGroup{},
+	}, nil
+}
+
+// Start begins watching the factory for new pairs
+func (d *DiscoveryService) Start() {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.watch()
+	}()
+}
+
+// Close stops watching the factory and terminates the discord session
+func (d *DiscoveryService) Close() error {
+	d.cancel()
+	d.wg.Wait()
+	return d.s.Close()
+}
+
+func (d *DiscoveryService) watch() {
+	// lastBlock is where we start backfilling from whenever the subscription is
+	// re-established. it is only accessed by the resubscribe goroutine
+	var lastBlock uint64
+	if block, err := d.db.LastPairBlock(); err == nil {
+		lastBlock = block
+	} else if block, err := d.bc.CurrentBlock(); err == nil {
+		lastBlock = block
+	}
+	tokens := make([]common.Address, 0, len(d.tokens))
+	for token := range d.tokens {
+		tokens = append(tokens, token)
+	}
+	sink := make(chan *uniswap.PairCreated)
+	sub := event.Resubscribe(time.Minute, func(ctx context.Context) (event.Subscription, error) {
+		sub, err := d.bc.Uniswap().WatchPairCreated(ctx, sink)
+		if err != nil {
+			log.Println("failed to subscribe to pair creation: ", err)
+			return nil, err
+		}
+		// pairs created while we were disconnected are backfilled, from the earliest pair that failed
+		// to be announced if any. if the backfill fails the subscription is retried without advancing
+		// lastBlock so the missed pairs aren't skipped
+		from := lastBlock
+		if block, err := d.db.PendingPairBlock(); err == nil && block < from {
+			from = block
+		}
+		head, err := d.bc.CurrentBlock()
+		if err != nil {
+			sub.Unsubscribe()
+			log.Println("failed to backfill pair creation: ", err)
+			return nil, err
+		}
+		pairs, err := d.bc.Uniswap().FilterPairCreated(ctx, from, head, tokens)
+		if err != nil {
+			sub.Unsubscribe()
+			log.Println("failed to backfill pair creation: ", err)
+			return nil, err
+		}
+		for _, pair := range pairs {
+			select {
+			case sink <- pair:
+			case <-ctx.Done():
+				return sub, nil
+			}
+		}
+		lastBlock = head
+		return sub, nil
+	})
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case pair := <-sink:
+			if pair.Removed || (!d.tokens[pair.Token0] && !d.tokens[pair.Token1]) {
+				continue
+			}
+			if err := d.handlePair(pair); err != nil {
+				log.Printf("failed to handle new pair %s: %s\n", pair.Pair, err)
+			}
+		}
+	}
+}
+
+func (d *DiscoveryService) handlePair(pair *uniswap.PairCreated) error {
+	tkn0, err := d.bc.TokenInfo(pair.Token0)
+	if err != nil {
+		return err
+	}
+	tkn1, err := d.bc.TokenInfo(pair.Token1)
+	if err != nil {
+		return err
+	}
+	// liquidity is usually added in the same transaction that creates the pair,
+	// so the reserves at the end of the creation block are the initial liquidity
+	var reserve0, reserve1 float64
+	reserves, err := d.bc.Uniswap().GetReservesAt(pair.Token0, pair.Token1, new(big.Int).SetUint64(pair.BlockNumber))
+	if err == nil {
+		reserve0, _ = utils.ToDecimal(reserves.Reserve0, tkn0.Decimals).Float64()
+		reserve1, _ = utils.ToDecimal(reserves.Reserve1, tkn1.Decimals).Float64()
+	}
+	isNew, err := d.db.RecordPair(&db.Pair{
+		Address:         pair.Pair.String(),
+		Token0:          pair.Token0.String(),
+		Token1:          pair.Token1.String(),
+		ChainID:         d.bc.Network().ChainID,
+		FirstSeenBlock:  pair.BlockNumber,
+		BlockHash:       pair.BlockHash.String(),
+		InitialReserve0: reserve0,
+		InitialReserve1: reserve1,
+		Pending:         true,
+	})
+	if err != nil {
+		return err
+	}
+	// pairs seen again are only announced if announcing them failed before
+	if !isNew {
+		pending, err := d.db.PairPending(pair.Pair.String())
+		if err != nil || !pending {
+			return err
+		}
+	}
+	if _, err := d.s.ChannelMessageSendEmbed(d.cfg.ChannelID, renderPairEmbed(d.cfg.ExplorerURL, tkn0, tkn1, pair, reserve0, reserve1)); err != nil {
+		return err
+	}
+	return d.db.MarkPairPosted(pair.Pair.String())
+}
+
+// renderPairEmbed renders the embed announcing a newly created pair
+func renderPairEmbed(explorerURL string, tkn0, tkn1 *bclient.Token, pair *uniswap.PairCreated, reserve0, reserve1 float64) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Type:      "rich",
+		Title:     fmt.Sprintf("New Pair %s/%s", tkn0.Symbol, tkn1.Symbol),
+		URL:       explorerURL + "/address/" + pair.Pair.String(),
+		Timestamp: time.Now().Format(time.RFC3339),
+		Color:     0x00ff00,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Pair",
+				Value:  fmt.Sprintf("[%s](%s/address/%s)", pair.Pair.String(), explorerURL, pair.Pair.String()),
+				Inline: false,
+			},
+			{
+				Name:   "Initial " + tkn0.Symbol + " Liquidity",
+				Value:  fmt.Sprintf("%.4f", reserve0),
+				Inline: true,
+			},
+			{
+				Name:   "Initial " + tkn1.Symbol + " Liquidity",
+				Value:  fmt.Sprintf("%.4f", reserve1),
+				Inline: true,
+			},
+			{
+				Name:   "Block",
+				Value:  fmt.Sprint(pair.BlockNumber),
+				Inline: true,
+			},
+			{
+				Name:   "Transaction",
+				Value:  fmt.Sprintf("[%s](%s/tx/%s)", pair.TxHash.String(), explorerURL, pair.TxHash.String()),
+				Inline: false,
+			},
+		},
+	}
+}
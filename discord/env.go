@@ -0,0 +1,168 @@
+package discord
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix prefixes the environment variables overriding config fields. The variable for a field
+// is named after its yaml path, ie UNIBOT_DATABASE_PASS or UNIBOT_WATCHERS_0_DISCORD_TOKEN, and
+// appending _FILE reads the value from a file, such as a mounted secret
+const EnvPrefix = "UNIBOT_"
+
+// legacyEnv are the environment variables fields were read from before the UNIBOT_ variables, which
+// are still read for compatibility but are overridden by the UNIBOT_ variables
+var legacyEnv = map[string]string{
+	"infura_api_key": "INFURA_API_KEY",
+	"discord_token":  "DISCORD_TOKEN",
+}
+
+// placeholder matches ${NAME} references to environment variables in config values
+var placeholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// EnvName returns the environment variable overriding the field at the yaml path, ie database.pass
+func EnvName(path string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "[", "_", "]", "").Replace(path))
+}
+
+// envPlaceholder returns a placeholder referencing the environment variable of the field at path
+func envPlaceholder(path string) string {
+	return "${" + EnvName(path) + "}"
+}
+
+// ApplyEnv layers the environment over the config loaded from yaml. ${NAME} placeholders in
+// string values are expanded first, then fields are overridden by the non-empty legacyEnv variables,
+// then by their UNIBOT_ variables, and finally by the contents of the files named by their
+// UNIBOT_*_FILE variables. Slices of strings
+// are set from comma separated values, while slices of structs can only override existing entries
+func (c *Config) ApplyEnv() error {
+	return walkFields(reflect.ValueOf(c).Elem(), "", func(path string, field reflect.Value) error {
+		switch values := field.Addr().Interface().(type) {
+		case *string:
+			*values = expand(*values)
+		case *[]string:
+			for i := range *values {
+				(*values)[i] = expand((*values)[i])
+			}
+		}
+		if value := os.Getenv(legacyEnv[path]); legacyEnv[path] != "" && value != "" {
+			if err := setField(field, value); err != nil {
+				return fmt.Errorf("%s: %w", legacyEnv[path], err)
+			}
+		}
+		name := EnvName(path)
+		if value, ok := os.LookupEnv(name); ok {
+			if err := setField(field, value); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		if file, ok := os.LookupEnv(name + "_FILE"); ok {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("%s_FILE: %w", name, err)
+			}
+			// files usually end with a newline that isn't part of the secret
+			if err := setField(field, strings.TrimRight(string(data), "\r\n")); err != nil {
+				return fmt.Errorf("%s_FILE: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// expand replaces the ${NAME} placeholders in the value with the environment variables they reference
+func expand(value string) string {
+	return placeholder.ReplaceAllStringFunc(value, func(ref string) string {
+		return os.Getenv(placeholder.FindStringSubmatch(ref)[1])
+	})
+}
+
+// walkFields calls fn with the yaml path of every field that isn't a struct or slice of structs,
+// descending into those instead
+func walkFields(v reflect.Value, path string, fn func(path string, field reflect.Value) error) error {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	for i := 0; i < v.NumField(); i++ {
+		key := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			if err := walkFields(field, join(key), fn); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < field.Len(); j++ {
+				if err := walkFields(field.Index(j), fmt.Sprintf("%s[%d]", join(key), j), fn); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := fn(join(key), field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setField parses the value into the field
+func setField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case int:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case uint32, uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case []string:
+		var values []string
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		field.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
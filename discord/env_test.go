@@ -0,0 +1,96 @@
+package discord
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"UNIBOT_INFURA_API_KEY":             "infura",
+		"UNIBOT_DATABASE_TYPE":              "postgres",
+		"UNIBOT_DEPTH_INTERVAL":             "1m",
+		"UNIBOT_WHALE_WATCH_ENABLED":        "true",
+		"UNIBOT_WATCHERS_0_DECIMALS":        "-12",
+		"UNIBOT_WATCHERS_0_FEE_TIER":        "500",
+		"UNIBOT_USD_ANCHORS":                "0x1, 0x2",
+		"UNIBOT_WATCHERS_0_DISCORD_TOKEN":   "from-env",
+		"UNIBOT_DATABASE_PASS":              "from-env",
+		"UNIBOT_ARBITRAGE_MIN_PROFIT_USD":   "12.5",
+		"UNIBOT_WATCHERS_1_DISCORD_TOKEN":   "ignored",
+		"UNIBOT_DISCOVERY_DISCORD_TOKEN":    "",
+		"UNIBOT_WATCHERS_0_PAIR_REFERENCED": "WETH/DAI",
+	}
+	dir, err := ioutil.TempDir("", "unibot")
+	require.NoError(t, err)
+	secret := filepath.Join(dir, "pass")
+	require.NoError(t, ioutil.WriteFile(secret, []byte("from-file\n"), 0600))
+	env["UNIBOT_DATABASE_PASS_FILE"] = secret
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		for name := range env {
+			os.Unsetenv(name)
+		}
+	})
+	for name, value := range env {
+		require.NoError(t, os.Setenv(name, value))
+	}
+
+	cfg := &Config{
+		Discovery: Discovery{DiscordToken: "from-yaml"},
+		Watchers: []Watcher{
+			{DiscordToken: "from-yaml", Pair: "${UNIBOT_WATCHERS_0_PAIR_REFERENCED}"},
+		},
+	}
+	require.NoError(t, cfg.ApplyEnv())
+	require.Equal(t, "infura", cfg.InfuraAPIKey)
+	require.Equal(t, "postgres", cfg.Database.Type)
+	require.Equal(t, time.Minute, cfg.DepthInterval)
+	require.True(t, cfg.WhaleWatch.Enabled)
+	require.Equal(t, -12, cfg.Watchers[0].Decimals)
+	require.Equal(t, uint32(500), cfg.Watchers[0].FeeTier)
+	require.Equal(t, []string{"0x1", "0x2"}, cfg.USDAnchors)
+	require.Equal(t, "from-env", cfg.Watchers[0].DiscordToken)
+	require.Equal(t, "WETH/DAI", cfg.Watchers[0].Pair)
+	require.Len(t, cfg.Watchers, 1)
+	require.Equal(t, 12.5, cfg.Arbitrage.MinProfitUSD)
+	// set but empty variables still override
+	require.Empty(t, cfg.Discovery.DiscordToken)
+	// files take precedence over variables
+	require.Equal(t, "from-file", cfg.Database.Pass)
+
+	require.NoError(t, os.Setenv("UNIBOT_DEPTH_INTERVAL", "soon"))
+	require.Error(t, cfg.ApplyEnv())
+	require.NoError(t, os.Setenv("UNIBOT_DEPTH_INTERVAL", "1m"))
+	require.NoError(t, os.Setenv("UNIBOT_DATABASE_PASS_FILE", filepath.Join(dir, "missing")))
+	require.Error(t, cfg.ApplyEnv())
+}
+
+func TestApplyLegacyEnv(t *testing.T) {
+	t.Cleanup(func() {
+		os.Unsetenv("DISCORD_TOKEN")
+		os.Unsetenv("UNIBOT_DISCORD_TOKEN")
+	})
+	cfg := &Config{DiscordToken: "from-yaml"}
+	// empty legacy variables are ignored
+	require.NoError(t, os.Setenv("DISCORD_TOKEN", ""))
+	require.NoError(t, cfg.ApplyEnv())
+	require.Equal(t, "from-yaml", cfg.DiscordToken)
+	require.NoError(t, os.Setenv("DISCORD_TOKEN", "from-legacy-env"))
+	require.NoError(t, cfg.ApplyEnv())
+	require.Equal(t, "from-legacy-env", cfg.DiscordToken)
+	require.NoError(t, os.Setenv("UNIBOT_DISCORD_TOKEN", "from-env"))
+	require.NoError(t, cfg.ApplyEnv())
+	require.Equal(t, "from-env", cfg.DiscordToken)
+}
+
+func TestEnvName(t *testing.T) {
+	require.Equal(t, "UNIBOT_DATABASE_PASS", EnvName("database.pass"))
+	require.Equal(t, "UNIBOT_WATCHERS_0_DISCORD_TOKEN", EnvName("watchers[0].discord_token"))
+	require.Equal(t, "${UNIBOT_INFURA_API_KEY}", envPlaceholder("infura_api_key"))
+}
@@ -0,0 +1,90 @@
+package discord
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (c *Client) gasHandler(args []string) reply {
+	fees, err := c.bc.GasFees()
+	if err != nil {
+		log.Println("failed to get gas fees: ", err)
+		return reply{text: "failed to get gas fees"}
+	}
+	tiers := []struct {
+		name  string
+		price *big.Int
+	}{
+		{"slow", fees.Slow()},
+		{"standard", fees.Standard()},
+		{"fast", fees.Fast()},
+	}
+	msg := fmt.Sprintf("gas as of block %v, base fee %s gwei\n", fees.Block, formatGwei(fees.BaseFee))
+	for _, tier := range tiers {
+		msg += fmt.Sprintf("%s: %s gwei", tier.name, formatGwei(tier.price))
+		if cost, err := c.bc.SwapCostUSD(tier.price); err == nil {
+			msg += fmt.Sprintf(" ($%.2f per swap)", cost)
+		}
+		msg += "\n"
+	}
+	return reply{text: msg}
+}
+
+// startGasBot shows the standard gas price as the nickname of the gas bot, refreshing it every
+// interval until the client is closed
+func (c *Client) startGasBot(interval time.Duration) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		dg, err := discordgo.New("Bot " + c.cfg.Gas.DiscordToken)
+		if err != nil {
+			log.Println("failed to start gas bot: ", err)
+			return
+		}
+		if err := dg.Open(); err != nil {
+			log.Println("failed to start gas bot: ", err)
+			return
+		}
+		defer dg.Close()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var shown string
+		for {
+			if fees, err := c.bc.GasFees(); err != nil {
+				log.Println("failed to get gas fees: ", err)
+			} else if nickname, status := gasPresence(fees); nickname+status != shown {
+				if err := setPresence(dg, nickname, status); err != nil {
+					log.Println("failed to show gas fees: ", err)
+				} else {
+					shown = nickname + status
+				}
+			}
+			select {
+			case <-c.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// gasPresence formats the standard gas price as a nickname, and the slow and fast prices as a status
+func gasPresence(fees *bclient.GasFees) (string, string) {
+	return fmt.Sprintf("⛽ %s gwei", formatGwei(fees.Standard())),
+		fmt.Sprintf("slow %s fast %s gwei", formatGwei(fees.Slow()), formatGwei(fees.Fast()))
+}
+
+// formatGwei formats the wei amount in gwei, with decimals when it is small as on layer 2 networks
+func formatGwei(wei *big.Int) string {
+	gwei, _ := utils.ToDecimal(wei, 9).Float64()
+	if gwei < 10 {
+		return fmt.Sprintf("%.2f", gwei)
+	}
+	return fmt.Sprintf("%.0f", gwei)
+}
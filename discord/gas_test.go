@@ -0,0 +1,17 @@
+package discord
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPresence(t *testing.T) {
+	fees := &bclient.GasFees{BaseFee: big.NewInt(30e9), SlowTip: big.NewInt(1e9), StandardTip: big.NewInt(2e9), FastTip: big.NewInt(5e9)}
+	nickname, status := gasPresence(fees)
+	require.Equal(t, "⛽ 32 gwei", nickname)
+	require.Equal(t, "slow 31 fast 35 gwei", status)
+	require.Equal(t, "0.15", formatGwei(big.NewInt(15e7)))
+}
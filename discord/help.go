@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"log"
 	"math"
 	"strconv"
 	"strings"
@@ -10,63 +11,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
-func registerHelpCommand(session *discordgo.Session, rateLimiter dgc.RateLimiter, router *dgc.Router) {
-	// Initialize the helo messages storage
-	router.InitializeStorage("dgc_helpMessages")
-
-	// Initialize the reaction add listener
-	session.AddHandler(func(session *discordgo.Session, event *discordgo.MessageReactionAdd) {
-		// Define useful variables
-		channelID := event.ChannelID
-		messageID := event.MessageID
-		userID := event.UserID
-
-		// Check whether or not the reaction was added by the bot itself
-		if event.UserID == session.State.User.ID {
-			return
-		}
-
-		// Check whether or not the message is a help message
-		rawPage, ok := router.Storage["dgc_helpMessages"].Get(channelID + ":" + messageID + ":" + event.UserID)
-		if !ok {
-			return
-		}
-		page := rawPage.(int)
-		if page <= 0 {
-			return
-		}
-
-		// Check which reaction was added
-		reactionName := event.Emoji.Name
-		switch reactionName {
-		case "⬅️":
-			// Update the help message
-			embed, newPage := renderDefaultGeneralHelpEmbed(router, page-1)
-			page = newPage
-			session.ChannelMessageEditEmbed(channelID, messageID, embed)
-
-			// Remove the reaction
-			session.MessageReactionRemove(channelID, messageID, reactionName, userID)
-			break
-		case "❌":
-			// Delete the help message
-			session.ChannelMessageDelete(channelID, messageID)
-			break
-		case "➡️":
-			// Update the help message
-			embed, newPage := renderDefaultGeneralHelpEmbed(router, page+1)
-			page = newPage
-			session.ChannelMessageEditEmbed(channelID, messageID, embed)
-
-			// Remove the reaction
-			session.MessageReactionRemove(channelID, messageID, reactionName, userID)
-			break
-		}
-
-		// Update the stores page
-		router.Storage["dgc_helpMessages"].Set(channelID+":"+messageID+":"+event.UserID, page)
-	})
-
+func registerHelpCommand(rateLimiter dgc.RateLimiter, router *dgc.Router) {
 	// Register the default help command
 	router.RegisterCmd(&dgc.Command{
 		Name:        "help",
@@ -88,21 +33,57 @@ func generalHelpCommand(ctx *dgc.Ctx) {
 		return
 	}
 
-	// Define useful variables
-	channelID := ctx.Event.ChannelID
-	session := ctx.Session
-
-	// Send the general help embed
-	embed, _ := renderDefaultGeneralHelpEmbed(ctx.Router, 1)
-	message, _ := ctx.Session.ChannelMessageSendEmbed(channelID, embed)
+	// Send the general help embed with the buttons paging it
+	embed, page := renderDefaultGeneralHelpEmbed(ctx.Router, 1)
+	buttons := helpButtons(ctx.Event.Author.ID, page, helpPages(ctx.Router))
+	if err := sendComponents(ctx.Session, ctx.Event.ChannelID, embed, buttons); err != nil {
+		log.Println("failed to send help: ", err)
+	}
+}
 
-	// Add the reactions to the message
-	session.MessageReactionAdd(channelID, message.ID, "⬅️")
-	session.MessageReactionAdd(channelID, message.ID, "❌")
-	session.MessageReactionAdd(channelID, message.ID, "➡️")
+// helpButtons returns the buttons paging the help and closing it. Their custom ids hold the
+// user who asked for the help, who is the only one allowed to use them, and the page they show
+func helpButtons(userID string, page, pageAmount int) []component {
+	button := func(emoji, action string, style int, disabled bool) component {
+		return component{
+			Type:     componentButton,
+			Style:    style,
+			Emoji:    &componentEmoji{Name: emoji},
+			CustomID: "help:" + userID + ":" + action,
+			Disabled: disabled,
+		}
+	}
+	return []component{{Type: componentActionRow, Components: []component{
+		button("⬅️", strconv.Itoa(page-1), buttonSecondary, page <= 1),
+		button("❌", "close", buttonDanger, false),
+		button("➡️", strconv.Itoa(page+1), buttonSecondary, page >= pageAmount),
+	}}}
+}
 
-	// Define the message as a help message
-	ctx.Router.Storage["dgc_helpMessages"].Set(channelID+":"+message.ID+":"+ctx.Event.Author.ID, 1)
+// helpButtonResponse pages or closes the help whose button was clicked
+func (c *Client) helpButtonResponse(i *interaction) *interactionResponse {
+	parts := strings.Split(i.Data.CustomID, ":")
+	if len(parts) != 3 || parts[0] != "help" {
+		return nil
+	}
+	if parts[1] != i.userID() {
+		return &interactionResponse{Type: responseMessage, Data: &interactionResponseData{
+			Content: "only the user who asked for help can use its buttons",
+			Flags:   flagEphemeral,
+		}}
+	}
+	if parts[2] == "close" {
+		return &interactionResponse{Type: responseDeferredUpdate}
+	}
+	page, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil
+	}
+	embed, page := renderDefaultGeneralHelpEmbed(c.router, page)
+	return &interactionResponse{Type: responseUpdateMessage, Data: &interactionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: helpButtons(parts[1], page, helpPages(c.router)),
+	}}
 }
 
 // specificHelpCommand handles the specific help command
@@ -131,7 +112,7 @@ func renderDefaultGeneralHelpEmbed(router *dgc.Router, page int) (*discordgo.Mes
 	prefix := router.Prefixes[0]
 
 	// Calculate the amount of pages
-	pageAmount := int(math.Ceil(float64(len(commands)) / 5))
+	pageAmount := helpPages(router)
 	if page > pageAmount {
 		page = pageAmount
 	}
@@ -171,6 +152,11 @@ func renderDefaultGeneralHelpEmbed(router *dgc.Router, page int) (*discordgo.Mes
 	}, page
 }
 
+// helpPages returns the amount of pages of the general help embed
+func helpPages(router *dgc.Router) int {
+	return int(math.Ceil(float64(len(router.Commands)) / 5))
+}
+
 // renderDefaultSpecificHelpEmbed renders the specific help embed of the given command
 func renderDefaultSpecificHelpEmbed(ctx *dgc.Ctx, command *dgc.Command) *discordgo.MessageEmbed {
 	// Define useful variables
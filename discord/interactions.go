@@ -0,0 +1,379 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bonedaddy/unibot/pricing"
+	"github.com/bwmarrin/discordgo"
+)
+
+// The discordgo version used predates application commands and message components, so they are
+// registered and answered through raw REST requests, and interactions are read from the raw
+// gateway events. Interactions require version 8 of the API while discordgo uses version 6
+const interactionsAPI = "https://discord.com/api/v8/"
+
+// interaction types
+const (
+	interactionCommand      = 2
+	interactionComponent    = 3
+	interactionAutocomplete = 4
+)
+
+// interaction response types
+const (
+	responseMessage         = 4
+	responseDeferredMessage = 5
+	responseDeferredUpdate  = 6
+	responseUpdateMessage   = 7
+	responseAutocomplete    = 8
+)
+
+// application command option types
+const (
+	optionString = 3
+)
+
+// component types and button styles
+const (
+	componentActionRow = 1
+	componentButton    = 2
+	buttonSecondary    = 2
+	buttonDanger       = 4
+)
+
+// flagEphemeral marks a response as only visible to the user who ran the command
+const flagEphemeral = 1 << 6
+
+// maxChoices is the most autocomplete choices discord accepts
+const maxChoices = 25
+
+// applicationCommand is a slash command as registered with discord
+type applicationCommand struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     []commandOption `json:"options,omitempty"`
+}
+
+// commandOption is a typed option of a slash command
+type commandOption struct {
+	Type         int            `json:"type"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	Required     bool           `json:"required,omitempty"`
+	Autocomplete bool           `json:"autocomplete,omitempty"`
+	Choices      []optionChoice `json:"choices,omitempty"`
+}
+
+// optionChoice is a value offered for an option
+type optionChoice struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// interaction is a slash command, button click or autocomplete request sent by discord
+type interaction struct {
+	ID            string          `json:"id"`
+	ApplicationID string          `json:"application_id"`
+	Type          int             `json:"type"`
+	Token         string          `json:"token"`
+	ChannelID     string          `json:"channel_id"`
+	Data          interactionData `json:"data"`
+	// Member is set for interactions in guilds and User for interactions in direct messages
+	Member  *discordgo.Member  `json:"member"`
+	User    *discordgo.User    `json:"user"`
+	Message *discordgo.Message `json:"message"`
+}
+
+// userID returns the id of the user who caused the interaction
+func (i *interaction) userID() string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// interactionData is the slash command or the custom id of the clicked button
+type interactionData struct {
+	Name     string              `json:"name"`
+	Options  []interactionOption `json:"options"`
+	CustomID string              `json:"custom_id"`
+}
+
+// interactionOption is the value of an option, all options of the registered commands are strings
+type interactionOption struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Focused bool   `json:"focused"`
+}
+
+// interactionResponse is the response to an interaction
+type interactionResponse struct {
+	Type int                      `json:"type"`
+	Data *interactionResponseData `json:"data,omitempty"`
+}
+
+type interactionResponseData struct {
+	Content    string                    `json:"content,omitempty"`
+	Embeds     []*discordgo.MessageEmbed `json:"embeds,omitempty"`
+	Flags      int                       `json:"flags,omitempty"`
+	Components []component               `json:"components,omitempty"`
+	Choices    []optionChoice            `json:"choices,omitempty"`
+}
+
+// component is an action row or a button of a message
+type component struct {
+	Type       int             `json:"type"`
+	Style      int             `json:"style,omitempty"`
+	Emoji      *componentEmoji `json:"emoji,omitempty"`
+	CustomID   string          `json:"custom_id,omitempty"`
+	Disabled   bool            `json:"disabled,omitempty"`
+	Components []component     `json:"components,omitempty"`
+}
+
+type componentEmoji struct {
+	Name string `json:"name"`
+}
+
+// slashCommand is a slash command and the command answering it. The options are passed to the
+// command as the arguments of the equivalent prefix command
+type slashCommand struct {
+	applicationCommand
+	handler func(args []string) reply
+	// ephemeral replies are only shown to the user who ran the command
+	ephemeral bool
+}
+
+// pairOption is the pair option of the commands taking a pair
+var pairOption = commandOption{
+	Type:         optionString,
+	Name:         "pair",
+	Description:  "a watched pair such as WETH/DAI, or two token addresses or ENS names",
+	Required:     true,
+	Autocomplete: true,
+}
+
+// slashCommands returns the slash commands, help is answered separately as its buttons are
+// specific to the user who asked for it
+func (c *Client) slashCommands() []slashCommand {
+	var currencies []optionChoice
+	for _, currency := range []pricing.Currency{pricing.USD, pricing.ETH, pricing.DAI, pricing.Native} {
+		currencies = append(currencies, optionChoice{Name: strings.ToUpper(string(currency)), Value: string(currency)})
+	}
+	return []slashCommand{
+		{applicationCommand: applicationCommand{
+			Name:        "price",
+			Description: "returns the current price of a pair",
+			Options: []commandOption{pairOption, {
+				Type:        optionString,
+				Name:        "currency",
+				Description: "the currency the price is shown in, defaults to USD",
+				Choices:     currencies,
+			}},
+		}, handler: c.priceHandler},
+		{applicationCommand: applicationCommand{
+			Name:        "quote",
+			Description: "compares swapping an amount of a token through the uniswap v2 pair and every v3 fee tier",
+			Options: []commandOption{
+				{Type: optionString, Name: "token_in", Description: "the address or ENS name of the token sold", Required: true},
+				{Type: optionString, Name: "token_out", Description: "the address or ENS name of the token bought", Required: true},
+				{Type: optionString, Name: "amount", Description: "the amount of token_in sold, such as 1.5", Required: true},
+			},
+		}, handler: c.quoteHandler},
+		{applicationCommand: applicationCommand{
+			Name:        "depth",
+			Description: "returns the trade size needed to move the price of a pair and an order book ladder",
+			Options:     []commandOption{pairOption},
+		}, handler: c.depthHandler},
+		{applicationCommand: applicationCommand{
+			Name:        "divergence",
+			Description: "compares the uniswap and chainlink ETH/USD prices",
+		}, handler: c.divergenceHandler},
+		{applicationCommand: applicationCommand{
+			Name:        "gas",
+			Description: "returns the slow, standard and fast gas prices and the USD cost of a swap at each",
+		}, handler: c.gasHandler},
+		{applicationCommand: applicationCommand{
+			Name:        "portfolio",
+			Description: "returns the USD value of an address's token and LP token balances",
+			Options: []commandOption{{
+				Type:        optionString,
+				Name:        "address",
+				Description: "an address or ENS name",
+				Required:    true,
+			}},
+		}, handler: c.portfolioHandler, ephemeral: true},
+	}
+}
+
+// registerSlashCommands replaces the bot's global slash commands with the given commands
+func registerSlashCommands(s *discordgo.Session, applicationID string, commands []slashCommand) error {
+	definitions := []applicationCommand{{Name: "help", Description: "lists the available commands"}}
+	for _, command := range commands {
+		definitions = append(definitions, command.applicationCommand)
+	}
+	endpoint := func(id string) string { return interactionsAPI + "applications/" + id + "/commands" }
+	_, err := s.RequestWithBucketID("PUT", endpoint(applicationID), definitions, endpoint(""))
+	return err
+}
+
+// handleEvent answers the interactions among the raw gateway events
+func (c *Client) handleEvent(s *discordgo.Session, event *discordgo.Event) {
+	if event.Type != "INTERACTION_CREATE" {
+		return
+	}
+	var i interaction
+	if err := json.Unmarshal(event.RawData, &i); err != nil {
+		log.Println("failed to decode interaction: ", err)
+		return
+	}
+	response, deferred := c.interactionResponse(&i)
+	if response == nil {
+		return
+	}
+	endpoint := func(id, token string) string {
+		return interactionsAPI + "interactions/" + id + "/" + token + "/callback"
+	}
+	if _, err := s.RequestWithBucketID("POST", endpoint(i.ID, i.Token), response, endpoint("", "")); err != nil {
+		log.Printf("failed to respond to interaction %s: %s\n", i.Data.Name+i.Data.CustomID, err)
+		return
+	}
+	// slash commands are acknowledged before they run, as discord only waits three seconds for a
+	// response, and the acknowledgement is then edited with their reply
+	if deferred != nil {
+		original := func(applicationID, token string) string {
+			return interactionsAPI + "webhooks/" + applicationID + "/" + token + "/messages/@original"
+		}
+		if _, err := s.RequestWithBucketID("PATCH", original(i.ApplicationID, i.Token), deferred(), original("", "")); err != nil {
+			log.Printf("failed to reply to interaction %s: %s\n", i.Data.Name, err)
+		}
+		return
+	}
+	// closing the help deletes its message once the click is acknowledged
+	if response.Type == responseDeferredUpdate && i.Message != nil {
+		if err := s.ChannelMessageDelete(i.ChannelID, i.Message.ID); err != nil {
+			log.Println("failed to close help: ", err)
+		}
+	}
+}
+
+// interactionResponse pages the help, or completes the pair being typed, or acknowledges the
+// slash command, returning the response to the interaction or nil if it can't be answered. For
+// slash commands it also returns the function running the command, whose reply replaces the
+// acknowledgement
+func (c *Client) interactionResponse(i *interaction) (*interactionResponse, func() *interactionResponseData) {
+	switch i.Type {
+	case interactionAutocomplete:
+		for _, option := range i.Data.Options {
+			if option.Focused && option.Name == pairOption.Name {
+				return &interactionResponse{Type: responseAutocomplete, Data: &interactionResponseData{Choices: c.pairChoices(option.Value)}}, nil
+			}
+		}
+		return &interactionResponse{Type: responseAutocomplete, Data: &interactionResponseData{Choices: []optionChoice{}}}, nil
+	case interactionComponent:
+		return c.helpButtonResponse(i), nil
+	case interactionCommand:
+	default:
+		return nil, nil
+	}
+	if i.Data.Name == "help" {
+		embed, page := renderDefaultGeneralHelpEmbed(c.router, 1)
+		return &interactionResponse{Type: responseMessage, Data: &interactionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: helpButtons(i.userID(), page, helpPages(c.router)),
+		}}, nil
+	}
+	for _, command := range c.slashCommands() {
+		if command.Name != i.Data.Name {
+			continue
+		}
+		if c.rateLimited(i.userID(), command.Name) {
+			return &interactionResponse{Type: responseMessage, Data: &interactionResponseData{Content: rateLimitMsg, Flags: flagEphemeral}}, nil
+		}
+		// the flags of the acknowledgement decide whether the reply is ephemeral
+		response := &interactionResponse{Type: responseDeferredMessage}
+		if command.ephemeral {
+			response.Data = &interactionResponseData{Flags: flagEphemeral}
+		}
+		args := slashArgs(command.applicationCommand, i.Data.Options)
+		return response, func() *interactionResponseData {
+			r := command.handler(args)
+			data := &interactionResponseData{Content: r.text}
+			if r.embed != nil {
+				data.Embeds = []*discordgo.MessageEmbed{r.embed}
+			}
+			return data
+		}
+	}
+	return nil, nil
+}
+
+// slashArgs returns the options of the slash command in the order they are defined, split into
+// the arguments of the equivalent prefix command
+func slashArgs(command applicationCommand, options []interactionOption) []string {
+	var args []string
+	for _, defined := range command.Options {
+		for _, option := range options {
+			if option.Name == defined.Name {
+				args = append(args, strings.Fields(option.Value)...)
+			}
+		}
+	}
+	return args
+}
+
+// pairChoices returns the names of the watched pairs starting with the prefix, ignoring case
+func (c *Client) pairChoices(prefix string) []optionChoice {
+	choices := []optionChoice{}
+	seen := make(map[string]bool)
+	for _, watcher := range c.watchers() {
+		name := watcher.Pair
+		if seen[name] || !strings.HasPrefix(strings.ToLower(name), strings.ToLower(strings.TrimSpace(prefix))) {
+			continue
+		}
+		seen[name] = true
+		choices = append(choices, optionChoice{Name: name, Value: name})
+		if len(choices) == maxChoices {
+			break
+		}
+	}
+	return choices
+}
+
+// rateLimited reports whether the user ran the command within the last minute, like the rate
+// limit of prefix commands, recording the run otherwise
+func (c *Client) rateLimited(userID, command string) bool {
+	c.lmux.Lock()
+	defer c.lmux.Unlock()
+	key := userID + ":" + command
+	if last, ok := c.limits[key]; ok && time.Since(last) < time.Minute {
+		return true
+	}
+	// runs older than the limit no longer matter, so they are dropped to keep the map small
+	for k, last := range c.limits {
+		if time.Since(last) >= time.Minute {
+			delete(c.limits, k)
+		}
+	}
+	c.limits[key] = time.Now()
+	return false
+}
+
+// sendComponents sends the embed with message components to the channel
+func sendComponents(s *discordgo.Session, channelID string, embed *discordgo.MessageEmbed, components []component) error {
+	endpoint := func(id string) string { return interactionsAPI + "channels/" + id + "/messages" }
+	_, err := s.RequestWithBucketID("POST", endpoint(channelID), struct {
+		Embed      *discordgo.MessageEmbed `json:"embed"`
+		Components []component             `json:"components"`
+	}{embed, components}, endpoint(channelID))
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return nil
+}
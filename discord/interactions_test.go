@@ -0,0 +1,108 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bonedaddy/dgc"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashArgs(t *testing.T) {
+	price := applicationCommand{Options: []commandOption{pairOption, {Name: "currency"}}}
+	// options are ordered as defined, and the pair may be two tokens
+	args := slashArgs(price, []interactionOption{{Name: "currency", Value: "eth"}, {Name: "pair", Value: "dai.eth  weth.eth"}})
+	require.Equal(t, []string{"dai.eth", "weth.eth", "eth"}, args)
+	require.Empty(t, slashArgs(price, nil))
+}
+
+func TestInteractionResponse(t *testing.T) {
+	router := dgc.Create(&dgc.Router{Prefixes: []string{"!ndx"}})
+	client := &Client{cfg: ExampleConfig, router: router, limits: make(map[string]time.Time)}
+	registerHelpCommand(nil, router)
+	client.registerCommands(router, nil)
+	user := &discordgo.Member{User: &discordgo.User{ID: "1"}}
+	command := func(name string, options ...interactionOption) *interaction {
+		return &interaction{Type: interactionCommand, Member: user, Data: interactionData{Name: name, Options: options}}
+	}
+
+	// pairs are completed from the watchers
+	response, deferred := client.interactionResponse(&interaction{Type: interactionAutocomplete, Data: interactionData{
+		Name:    "price",
+		Options: []interactionOption{{Name: "pair", Value: "we", Focused: true}},
+	}})
+	require.Equal(t, responseAutocomplete, response.Type)
+	require.Equal(t, []optionChoice{{Name: "WETH/DAI", Value: "WETH/DAI"}}, response.Data.Choices)
+	require.Nil(t, deferred)
+
+	// commands are acknowledged before they run
+	response, deferred = client.interactionResponse(command("price", interactionOption{Name: "pair", Value: "FOO/BAR"}))
+	require.Equal(t, responseDeferredMessage, response.Type)
+	require.Nil(t, response.Data)
+	require.Equal(t, "unknown pair: FOO/BAR", deferred().Content)
+	// like prefix commands each command can be run once a minute
+	response, deferred = client.interactionResponse(command("price", interactionOption{Name: "pair", Value: "FOO/BAR"}))
+	require.Equal(t, responseMessage, response.Type)
+	require.Equal(t, rateLimitMsg, response.Data.Content)
+	require.Equal(t, flagEphemeral, response.Data.Flags)
+	require.Nil(t, deferred)
+
+	// personal data is only shown to the user
+	response, deferred = client.interactionResponse(command("portfolio", interactionOption{Name: "address", Value: "nope"}))
+	require.Equal(t, responseDeferredMessage, response.Type)
+	require.Equal(t, flagEphemeral, response.Data.Flags)
+	require.Equal(t, errInvalidAddress.Error(), deferred().Content)
+	response, _ = client.interactionResponse(command("unknown"))
+	require.Nil(t, response)
+
+	response, _ = client.interactionResponse(command("help"))
+	require.Len(t, response.Data.Embeds, 1)
+	buttons := response.Data.Components[0].Components
+	require.Len(t, buttons, 3)
+	require.True(t, buttons[0].Disabled)
+	require.Equal(t, "help:1:2", buttons[2].CustomID)
+}
+
+func TestHelpButtonResponse(t *testing.T) {
+	router := dgc.Create(&dgc.Router{Prefixes: []string{"!ndx"}})
+	client := &Client{cfg: ExampleConfig, router: router}
+	registerHelpCommand(nil, router)
+	client.registerCommands(router, nil)
+	pages := helpPages(router)
+	require.Equal(t, 2, pages)
+	click := func(userID, customID string) *interactionResponse {
+		response, _ := client.interactionResponse(&interaction{
+			Type: interactionComponent,
+			User: &discordgo.User{ID: userID},
+			Data: interactionData{CustomID: customID},
+		})
+		return response
+	}
+
+	response := click("1", "help:1:2")
+	require.Equal(t, responseUpdateMessage, response.Type)
+	require.Contains(t, response.Data.Embeds[0].Title, "Page 2/2")
+	buttons := response.Data.Components[0].Components
+	require.False(t, buttons[0].Disabled)
+	require.Equal(t, "help:1:1", buttons[0].CustomID)
+	require.True(t, buttons[2].Disabled)
+
+	// only the user who asked for help can page it
+	response = click("2", "help:1:1")
+	require.Equal(t, responseMessage, response.Type)
+	require.Equal(t, flagEphemeral, response.Data.Flags)
+
+	require.Equal(t, responseDeferredUpdate, click("1", "help:1:close").Type)
+	require.Nil(t, click("1", "other"))
+}
+
+func TestRateLimited(t *testing.T) {
+	client := &Client{limits: map[string]time.Time{"2:price": time.Now().Add(-time.Hour)}}
+	require.False(t, client.rateLimited("1", "price"))
+	require.True(t, client.rateLimited("1", "price"))
+	require.False(t, client.rateLimited("1", "gas"))
+	// expired runs are dropped
+	require.Len(t, client.limits, 2)
+	require.NotContains(t, client.limits, "2:price")
+}
@@ -0,0 +1,83 @@
+package discord
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxEmbedFields is the most fields discord allows in an embed
+const maxEmbedFields = 25
+
+// displayAddress formats the address truncated to its first and last four digits, after its ENS
+// name if it has one
+func displayAddress(address common.Address, name string) string {
+	hex := address.Hex()
+	short := hex[:6] + "…" + hex[len(hex)-4:]
+	if name == "" {
+		return short
+	}
+	return name + " (" + short + ")"
+}
+
+func (c *Client) portfolioHandler(args []string) reply {
+	if len(args) != 1 {
+		return reply{text: "invalid invocation, expected an address"}
+	}
+	address, err := c.resolveAddress(args[0])
+	if err != nil {
+		return reply{text: err.Error()}
+	}
+	account := common.HexToAddress(address)
+	name := args[0]
+	if !utils.IsENSName(name) {
+		// not fatal, the account is shown without a name
+		name, _ = c.bc.LookupAddress(account)
+	}
+	tokens, pairs := c.cfg.PortfolioAssets(c.bc.Network())
+	portfolio, err := c.bc.Portfolio(account, tokens, pairs)
+	if err != nil {
+		log.Printf("failed to get portfolio of %s - %s\n", account, err)
+		return reply{text: "failed to get portfolio"}
+	}
+	return reply{embed: renderPortfolioEmbed(c.cfg.WhaleWatch.ExplorerURL, portfolio, name)}
+}
+
+// renderPortfolioEmbed renders the embed listing the value of each holding of a portfolio and
+// their total, titled with the account's ENS name if it has one. The smallest holdings are left
+// out if there are more than an embed can fit
+func renderPortfolioEmbed(explorerURL string, portfolio *bclient.Portfolio, name string) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Type:      "rich",
+		Title:     "Portfolio " + displayAddress(portfolio.Account, name),
+		Timestamp: portfolio.Fetched.Format(time.RFC3339),
+		Color:     0x00ff00,
+	}
+	if explorerURL != "" {
+		embed.URL = explorerURL + "/address/" + portfolio.Account.String()
+	}
+	if len(portfolio.Holdings) == 0 {
+		embed.Description = "no holdings"
+	}
+	for _, holding := range portfolio.Holdings {
+		if len(embed.Fields) == maxEmbedFields-1 {
+			break
+		}
+		value := fmt.Sprintf("%.4f", holding.Balance)
+		if holding.Priced {
+			value += fmt.Sprintf(" ($%.2f)", holding.USDValue)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: holding.Asset, Value: value, Inline: true})
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:   "Total",
+		Value:  fmt.Sprintf("$%.2f", portfolio.TotalUSD),
+		Inline: false,
+	})
+	return embed
+}
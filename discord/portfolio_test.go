@@ -0,0 +1,39 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPortfolioEmbed(t *testing.T) {
+	portfolio := &bclient.Portfolio{
+		Account: common.HexToAddress("0x5a361A1dfd52538A158e352d21B5b622360a7C13"),
+		Holdings: []bclient.Holding{
+			{Asset: "WETH", Balance: 1, USDValue: 2000, Priced: true},
+			{Asset: "XYZ", Balance: 5},
+		},
+		TotalUSD: 2000,
+		Fetched:  time.Now(),
+	}
+	embed := renderPortfolioEmbed("https://etherscan.io", portfolio, "")
+	require.Equal(t, "Portfolio 0x5a36…7C13", embed.Title)
+	require.Len(t, embed.Fields, 3)
+	require.Equal(t, "1.0000 ($2000.00)", embed.Fields[0].Value)
+	// unpriced holdings are listed without a value
+	require.Equal(t, "5.0000", embed.Fields[1].Value)
+	require.Equal(t, "$2000.00", embed.Fields[2].Value)
+
+	// holdings that don't fit are left out, keeping the total
+	for i := 0; i < 30; i++ {
+		portfolio.Holdings = append(portfolio.Holdings, bclient.Holding{Asset: "XYZ"})
+	}
+	embed = renderPortfolioEmbed("", portfolio, "bonedaddy.eth")
+	require.Equal(t, "Portfolio bonedaddy.eth (0x5a36…7C13)", embed.Title)
+	require.Len(t, embed.Fields, maxEmbedFields)
+	require.Equal(t, "Total", embed.Fields[maxEmbedFields-1].Name)
+	require.Empty(t, embed.URL)
+}
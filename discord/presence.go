@@ -0,0 +1,188 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/health"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxNicknameLength is the longest nickname discord accepts
+const maxNicknameLength = 32
+
+// FollowPrices shows the prices received from the channel on the watcher bots of their pairs,
+// until the channel is closed or the client is closed. This is used when the watcher service
+// runs in the same process, so prices are shown as soon as they are recorded
+func (c *Client) FollowPrices(prices <-chan *db.Price) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		// prices stop arriving when their source stalls, so staleness is checked separately
+		ticker := time.NewTicker(c.cfg.staleAfter() / 10)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case price, ok := <-prices:
+				if !ok {
+					return
+				}
+				c.showPrice(price)
+			case <-ticker.C:
+				c.showStale()
+			}
+		}
+	}()
+}
+
+// PollPrices shows the last price recorded in the database for every watcher on its bot,
+// checking every interval until the client is closed. This is used when the watcher service
+// runs in a separate process
+func (c *Client) PollPrices(interval time.Duration) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case <-ticker.C:
+				for _, watcher := range c.watchers() {
+					price, err := c.db.LastObservation(watcher.Token0Address, watcher.Token1Address)
+					if err != nil {
+						continue
+					}
+					c.showPrice(price)
+				}
+				c.showStale()
+			}
+		}
+	}()
+}
+
+// showPrice updates the nickname and status of the bots of the watchers for the price's pair
+func (c *Client) showPrice(price *db.Price) {
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	for _, watcher := range c.cfg.Watchers {
+		if !strings.EqualFold(watcher.Token0Address, price.Token0) || !strings.EqualFold(watcher.Token1Address, price.Token1) {
+			continue
+		}
+		c.prices[watcher] = price
+		c.show(watcher)
+	}
+}
+
+// showStale marks the prices of watchers that haven't been updated recently as stale
+func (c *Client) showStale() {
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	for watcher := range c.prices {
+		c.show(watcher)
+	}
+}
+
+// show updates the bot of the watcher with its last price, skipping bots already showing it.
+// wmux must be held
+func (c *Client) show(watcher Watcher) {
+	dg, ok := c.sessions[watcher]
+	price := c.prices[watcher]
+	if !ok || price == nil {
+		return
+	}
+	nickname := priceNickname(watcher.Pair, price.NativePrice)
+	status := priceStatus(price, c.cfg.staleAfter())
+	if c.shown[watcher] == nickname+"\n"+status {
+		return
+	}
+	if err := setPresence(dg, nickname, status); err != nil {
+		log.Printf("failed to show price for pair %s: %s\n", watcher.Pair, err)
+		return
+	}
+	c.shown[watcher] = nickname + "\n" + status
+}
+
+// setPresence sets the bot's nickname in every guild it is in, and its status
+func setPresence(dg *discordgo.Session, nickname, status string) error {
+	dg.State.RLock()
+	guilds := make([]string, 0, len(dg.State.Guilds))
+	for _, guild := range dg.State.Guilds {
+		guilds = append(guilds, guild.ID)
+	}
+	dg.State.RUnlock()
+	for _, guild := range guilds {
+		if err := dg.GuildMemberNickname(guild, "@me", nickname); err != nil {
+			return err
+		}
+	}
+	return dg.UpdateStatus(0, status)
+}
+
+// priceNickname formats the price of the pair as a nickname, truncated to the length discord allows
+func priceNickname(pair string, price float64) string {
+	var nickname string
+	switch {
+	case price >= 1000:
+		nickname = fmt.Sprintf("%s %.0f", pair, price)
+	case price >= 1:
+		nickname = fmt.Sprintf("%s %.2f", pair, price)
+	default:
+		nickname = fmt.Sprintf("%s %.6f", pair, price)
+	}
+	if len(nickname) > maxNicknameLength {
+		nickname = nickname[:maxNicknameLength]
+	}
+	return nickname
+}
+
+// priceStatus formats the USD price as a status, flagging it as stale if it was recorded more
+// than staleAfter ago
+func priceStatus(price *db.Price, staleAfter time.Duration) string {
+	if time.Since(price.CreatedAt) > staleAfter {
+		return fmt.Sprintf("stale $%.2f USD", price.USDPrice)
+	}
+	return fmt.Sprintf("$%.2f USD", price.USDPrice)
+}
+
+// Health returns the status of the command bot's session and every watcher bot's session
+func (c *Client) Health(ctx context.Context) []health.Status {
+	var statuses []health.Status
+	if c.s != nil {
+		statuses = append(statuses, sessionStatus("discord:ndx", c.s))
+	}
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	for _, watcher := range c.cfg.Watchers {
+		component := "discord:" + watcher.Pair
+		dg, ok := c.sessions[watcher]
+		if !ok {
+			statuses = append(statuses, health.Status{Component: component, Error: "not connected"})
+			continue
+		}
+		statuses = append(statuses, sessionStatus(component, dg))
+	}
+	return statuses
+}
+
+// sessionStatus returns whether the session is connected to the gateway
+func sessionStatus(component string, dg *discordgo.Session) health.Status {
+	dg.RLock()
+	defer dg.RUnlock()
+	status := health.Status{Component: component, Healthy: dg.DataReady}
+	if !dg.LastHeartbeatAck.IsZero() {
+		ack := dg.LastHeartbeatAck
+		status.Updated = &ack
+	}
+	if !status.Healthy {
+		status.Error = "disconnected from gateway"
+	}
+	return status
+}
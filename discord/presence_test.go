@@ -0,0 +1,51 @@
+package discord
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceNickname(t *testing.T) {
+	require.Equal(t, "WETH/DAI 2001", priceNickname("WETH/DAI", 2000.51))
+	require.Equal(t, "DEFI5/DAI 12.35", priceNickname("DEFI5/DAI", 12.345))
+	require.Equal(t, "DEFI5/WETH 0.012346", priceNickname("DEFI5/WETH", 0.0123456))
+	require.Len(t, priceNickname("A-VERY-LONG-TOKEN-NAME/ANOTHER-ONE", 1), maxNicknameLength)
+}
+
+func TestFollowPrices(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &Client{cfg: ExampleConfig, wg: &sync.WaitGroup{}, ctx: ctx, cancel: cancel, prices: make(map[Watcher]*db.Price)}
+	prices := make(chan *db.Price, 1)
+	client.FollowPrices(prices)
+	// prices for pairs without a running bot are ignored
+	prices <- &db.Price{Token0: ExampleConfig.Watchers[0].Token0Address, Token1: ExampleConfig.Watchers[0].Token1Address}
+	close(prices)
+	client.wg.Wait()
+	require.Len(t, client.prices, 1)
+}
+
+func TestPriceStatus(t *testing.T) {
+	price := &db.Price{USDPrice: 2000.511}
+	price.CreatedAt = time.Now().Add(-time.Minute)
+	require.Equal(t, "$2000.51 USD", priceStatus(price, time.Minute*5))
+	require.Equal(t, "stale $2000.51 USD", priceStatus(price, time.Second*30))
+}
+
+func TestClientHealth(t *testing.T) {
+	client := &Client{cfg: ExampleConfig, sessions: make(map[Watcher]*discordgo.Session)}
+	statuses := client.Health(context.Background())
+	require.Len(t, statuses, 1)
+	require.Equal(t, "discord:WETH/DAI", statuses[0].Component)
+	require.False(t, statuses[0].Healthy)
+
+	client.sessions[ExampleConfig.Watchers[0]] = &discordgo.Session{DataReady: true}
+	statuses = client.Health(context.Background())
+	require.True(t, statuses[0].Healthy)
+}
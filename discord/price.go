@@ -0,0 +1,269 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bonedaddy/dgc"
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/pricing"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// errUnknownPair is returned when a pair name doesn't match any configured watcher
+	errUnknownPair = errors.New("unknown pair")
+	// errInvalidAddress is returned when a token argument is not a valid address
+	errInvalidAddress = errors.New("invalid token address")
+)
+
+// reply is the response to a command, sent as a message to prefix commands and as the
+// interaction response to slash commands
+type reply struct {
+	text  string
+	embed *discordgo.MessageEmbed
+}
+
+// textHandler adapts a command to the prefix command router
+func textHandler(command func(args []string) reply) dgc.ExecutionHandler {
+	return func(ctx *dgc.Ctx) {
+		r := command(commandArgs(ctx.Arguments))
+		var err error
+		if r.embed != nil {
+			err = ctx.RespondEmbed(r.embed)
+		} else {
+			err = ctx.RespondText(r.text)
+		}
+		if err != nil {
+			log.Println("failed to respond to command: ", err)
+		}
+	}
+}
+
+func (c *Client) registerCommands(router *dgc.Router, rateLimiter dgc.RateLimiter) {
+	router.RegisterCmd(&dgc.Command{
+		Name:        "price",
+		Description: "returns the current price of a pair",
+		Usage:       " price <pair | token0 token1> [usd | eth | dai | native], tokens may be ENS names",
+		Example:     " price WETH/DAI eth",
+		IgnoreCase:  true,
+		RateLimiter: rateLimiter,
+		Handler:     textHandler(c.priceHandler),
+	})
+	router.RegisterCmd(&dgc.Command{
+		Name:        "quote",
+		Description: "compares swapping an amount of a token through the uniswap v2 pair and every v3 fee tier",
+		Usage:       " quote <tokenIn> <tokenOut> <amount>, tokens may be ENS names",
+		Example:     " quote weth.eth dai.eth 1.5",
+		IgnoreCase:  true,
+		RateLimiter: rateLimiter,
+		Handler:     textHandler(c.quoteHandler),
+	})
+	router.RegisterCmd(&dgc.Command{
+		Name:        "divergence",
+		Description: "compares the uniswap and chainlink ETH/USD prices",
+		Usage:       " divergence",
+		Example:     " divergence",
+		IgnoreCase:  true,
+		RateLimiter: rateLimiter,
+		Handler:     textHandler(c.divergenceHandler),
+	})
+	router.RegisterCmd(&dgc.Command{
+		Name:        "depth",
+		Description: "returns the trade size needed to move the price of a pair and an order book ladder",
+		Usage:       " depth <pair | token0 token1>",
+		Example:     " depth DEFI5/WETH",
+		IgnoreCase:  true,
+		RateLimiter: rateLimiter,
+		Handler:     textHandler(c.depthHandler),
+	})
+	router.RegisterCmd(&dgc.Command{
+		Name:        "gas",
+		Description: "returns the slow, standard and fast gas prices and the USD cost of a uniswap swap at each",
+		Usage:       " gas",
+		Example:     " gas",
+		IgnoreCase:  true,
+		RateLimiter: rateLimiter,
+		Handler:     textHandler(c.gasHandler),
+	})
+	router.RegisterCmd(&dgc.Command{
+		Name:        "portfolio",
+		Description: "returns the USD value of an address's token and LP token balances",
+		Usage:       " portfolio <address | ENS name>",
+		Example:     " portfolio 0x5a361A1dfd52538A158e352d21B5b622360a7C13",
+		IgnoreCase:  true,
+		RateLimiter: rateLimiter,
+		Handler:     textHandler(c.portfolioHandler),
+	})
+}
+
+func (c *Client) priceHandler(args []string) reply {
+	args, currency, err := splitCurrency(args)
+	if err != nil {
+		return reply{text: err.Error()}
+	}
+	token0, token1, name, err := c.resolvePair(args)
+	if err != nil {
+		return reply{text: err.Error()}
+	}
+	price, err := c.bc.PairPrice(token0, token1)
+	if err == nil {
+		price, err = c.conv.Convert(price, common.HexToAddress(token1), currency)
+	}
+	if err != nil {
+		if errors.Is(err, uniswap.ErrPairNotFound) {
+			return reply{text: "no such pair"}
+		}
+		log.Printf("failed to get price for token0: %s token1: %s - %s\n", token0, token1, err)
+		return reply{text: "failed to get price"}
+	}
+	return reply{text: fmt.Sprintf("%s price: %f %s", name, price, strings.ToUpper(string(currency)))}
+}
+
+func (c *Client) quoteHandler(args []string) reply {
+	if len(args) != 3 {
+		return reply{text: "invalid invocation, expected tokenIn, tokenOut and amount"}
+	}
+	tokenIn, err := c.resolveAddress(args[0])
+	if err != nil {
+		return reply{text: err.Error()}
+	}
+	tokenOut, err := c.resolveAddress(args[1])
+	if err != nil {
+		return reply{text: err.Error()}
+	}
+	tknIn, err := c.bc.TokenInfo(common.HexToAddress(tokenIn))
+	if err != nil {
+		log.Printf("failed to get token info for %s - %s\n", tokenIn, err)
+		return reply{text: "failed to get quote"}
+	}
+	tknOut, err := c.bc.TokenInfo(common.HexToAddress(tokenOut))
+	if err != nil {
+		log.Printf("failed to get token info for %s - %s\n", tokenOut, err)
+		return reply{text: "failed to get quote"}
+	}
+	amountIn, err := utils.ParseAmount(args[2], tknIn.Decimals)
+	if err != nil {
+		return reply{text: err.Error()}
+	}
+	quotes, err := c.bc.Uniswap().CompareVenues(amountIn, tknIn.Address, tknOut.Address)
+	if err != nil {
+		if errors.Is(err, uniswap.ErrPairNotFound) {
+			return reply{text: "no such pair"}
+		}
+		log.Printf("failed to get quote for tokenIn: %s tokenOut: %s - %s\n", tokenIn, tokenOut, err)
+		return reply{text: "failed to get quote"}
+	}
+	msg := fmt.Sprintf("%s %s to %s\n", args[2], tknIn.Symbol, tknOut.Symbol)
+	for _, quote := range quotes {
+		msg += fmt.Sprintf("%s: %s %s\n", quote.Venue, utils.ToDecimal(quote.AmountOut, tknOut.Decimals), tknOut.Symbol)
+	}
+	return reply{text: msg}
+}
+
+func (c *Client) divergenceHandler(args []string) reply {
+	prices := pricing.NewMedianSource(
+		0,
+		pricing.NewUniswapSource(c.bc, bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String()),
+		pricing.NewChainlinkSource(c.bc, bclient.ChainlinkETHUSDFeed, 0),
+	).Prices()
+	uniswapPrice, ok := prices["uniswap"]
+	if !ok {
+		return reply{text: "failed to get uniswap price"}
+	}
+	chainlinkPrice, ok := prices["chainlink"]
+	if !ok {
+		return reply{text: "failed to get chainlink price"}
+	}
+	return reply{text: fmt.Sprintf(
+		"ETH/USD uniswap: %.2f chainlink: %.2f divergence: %.2f%%",
+		uniswapPrice, chainlinkPrice, pricing.Deviation(uniswapPrice, chainlinkPrice)*100,
+	)}
+}
+
+func (c *Client) depthHandler(args []string) reply {
+	token0, token1, _, err := c.resolvePair(args)
+	if err != nil {
+		return reply{text: err.Error()}
+	}
+	depth, err := c.bc.Depth(token0, token1, 0.01, 5)
+	if err != nil {
+		if errors.Is(err, uniswap.ErrPairNotFound) {
+			return reply{text: "no such pair"}
+		}
+		log.Printf("failed to get depth for token0: %s token1: %s - %s\n", token0, token1, err)
+		return reply{text: "failed to get depth"}
+	}
+	return reply{text: "```\n" + depth.Table() + "```"}
+}
+
+// resolvePair parses command arguments that are either the name of a configured watcher, or a
+// pair of token addresses or ENS names, returning the token addresses and pair name
+func (c *Client) resolvePair(args []string) (string, string, string, error) {
+	switch len(args) {
+	case 1:
+		for _, watcher := range c.watchers() {
+			if strings.EqualFold(watcher.Pair, args[0]) {
+				return watcher.Token0Address, watcher.Token1Address, watcher.Pair, nil
+			}
+		}
+		return "", "", "", fmt.Errorf("%w: %s", errUnknownPair, args[0])
+	case 2:
+		token0, err := c.resolveAddress(args[0])
+		if err != nil {
+			return "", "", "", err
+		}
+		token1, err := c.resolveAddress(args[1])
+		if err != nil {
+			return "", "", "", err
+		}
+		return token0, token1, args[0] + "/" + args[1], nil
+	default:
+		return "", "", "", errors.New("invalid invocation, expected a pair name or two token addresses")
+	}
+}
+
+// resolveAddress returns the hex address of a command argument that is an address or ENS name
+func (c *Client) resolveAddress(arg string) (string, error) {
+	if utils.IsValidAddress(arg) {
+		return arg, nil
+	}
+	if !utils.IsENSName(arg) {
+		return "", errInvalidAddress
+	}
+	address, err := c.bc.ResolveName(arg)
+	if err != nil {
+		return "", err
+	}
+	return address.Hex(), nil
+}
+
+// splitCurrency strips an optional trailing currency from the arguments, defaulting to USD
+func splitCurrency(args []string) ([]string, pricing.Currency, error) {
+	if len(args) == 0 {
+		return args, pricing.USD, nil
+	}
+	last := args[len(args)-1]
+	if utils.IsValidAddress(last) || utils.IsENSName(last) || strings.Contains(last, "/") {
+		return args, pricing.USD, nil
+	}
+	currency, err := pricing.ParseCurrency(last)
+	if err != nil {
+		return nil, "", err
+	}
+	return args[:len(args)-1], currency, nil
+}
+
+// commandArgs returns the raw command arguments
+func commandArgs(args *dgc.Arguments) []string {
+	raw := make([]string, 0, args.Amount())
+	for i := 0; i < args.Amount(); i++ {
+		raw = append(raw, args.Get(i).Raw())
+	}
+	return raw
+}
@@ -0,0 +1,89 @@
+package discord
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bonedaddy/dgc"
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/bonedaddy/unibot/pricing"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePair(t *testing.T) {
+	client := &Client{cfg: ExampleConfig}
+	tests := []struct {
+		name       string
+		args       string
+		wantToken0 string
+		wantErr    error
+	}{
+		{"pair-name", "weth/dai", bclient.WETHTokenAddress.String(), nil},
+		{"addresses", bclient.DAITokenAddress.String() + " " + bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String(), nil},
+		{"unknown-pair", "FOO/BAR", "", errUnknownPair},
+		{"invalid-address", "0xabc " + bclient.WETHTokenAddress.String(), "", errInvalidAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token0, _, _, err := client.resolvePair(commandArgs(dgc.ParseArguments(tt.args)))
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantToken0, token0)
+		})
+	}
+}
+
+func TestSplitCurrency(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantArgs     int
+		wantCurrency pricing.Currency
+		wantErr      bool
+	}{
+		{"default", []string{"WETH/DAI"}, 1, pricing.USD, false},
+		{"eth", []string{"WETH/DAI", "ETH"}, 1, pricing.ETH, false},
+		{"addresses", []string{bclient.DAITokenAddress.String(), bclient.WETHTokenAddress.String()}, 2, pricing.USD, false},
+		{"addresses-dai", []string{bclient.DAITokenAddress.String(), bclient.WETHTokenAddress.String(), "dai"}, 2, pricing.DAI, false},
+		{"ens-names", []string{"dai.eth", "weth.eth"}, 2, pricing.USD, false},
+		{"unsupported", []string{"WETH/DAI", "eur"}, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, currency, err := splitCurrency(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitCurrency() err %v, wantErr %v", err, tt.wantErr)
+			}
+			require.Len(t, args, tt.wantArgs)
+			require.Equal(t, tt.wantCurrency, currency)
+		})
+	}
+}
+
+func TestQuoteHandler(t *testing.T) {
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	bc := h.Client()
+	defer bc.Close()
+	_, err = h.SeedPools(harness.Pool{
+		TokenA:  bclient.WETHTokenAddress,
+		TokenB:  bclient.DAITokenAddress,
+		AmountA: utils.ToWei(int64(100), 18),
+		AmountB: utils.ToWei(int64(200000), 18),
+	})
+	require.NoError(t, err)
+	client := &Client{cfg: ExampleConfig, bc: bc}
+	weth, dai := bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String()
+
+	// 1 WETH buys a little under 2000 DAI after the fee and price impact
+	r := client.quoteHandler([]string{weth, dai, "1"})
+	require.Contains(t, r.text, "v2: 1974.")
+	require.Equal(t, "invalid invocation, expected tokenIn, tokenOut and amount", client.quoteHandler([]string{weth, dai}).text)
+	require.Contains(t, client.quoteHandler([]string{weth, dai, "-1"}).text, "positive")
+	require.Equal(t, "no such pair", client.quoteHandler([]string{weth, bclient.USDCTokenAddress.String(), "1"}).text)
+}
@@ -0,0 +1,107 @@
+package discord
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay is how long to wait for writes to the config file to settle before reloading it,
+// as editors often save a file with several writes or by renaming a temporary file over it
+const reloadDelay = time.Millisecond * 250
+
+// DiffWatchers returns the watchers in next that aren't in prev, and the watchers in prev that
+// aren't in next. A watcher whose settings changed is both removed and added
+func DiffWatchers(prev, next []Watcher) (added, removed []Watcher) {
+	contains := func(watchers []Watcher, watcher Watcher) bool {
+		for _, w := range watchers {
+			if w == watcher {
+				return true
+			}
+		}
+		return false
+	}
+	for _, watcher := range next {
+		if !contains(prev, watcher) {
+			added = append(added, watcher)
+		}
+	}
+	for _, watcher := range prev {
+		if !contains(next, watcher) {
+			removed = append(removed, watcher)
+		}
+	}
+	return added, removed
+}
+
+// WatchConfig reloads the config whenever the file at path changes or the process receives
+// SIGHUP, until the context is cancelled. The config is reloaded with load, which should apply
+// the same layers over the file as were applied at startup. Each new config that passes Validate
+// is passed to reload, while invalid configs are logged and ignored so the running set isn't disturbed
+func WatchConfig(ctx context.Context, path string, load func() (*Config, error), reload func(*Config)) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// the directory is watched as the file is replaced rather than written to by some editors
+	if err := fw.Add(filepath.Dir(path)); err != nil {
+		fw.Close()
+		return err
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer fw.Close()
+		defer signal.Stop(hup)
+		// debounce is armed by file events and fires once they settle
+		debounce := time.NewTimer(reloadDelay)
+		debounce.Stop()
+		apply := func(reason string) {
+			cfg, err := load()
+			if err == nil {
+				err = cfg.Validate()
+			}
+			if err != nil {
+				log.Printf("rejected config reload (%s): %s\n", reason, err)
+				return
+			}
+			log.Printf("reloading config (%s)\n", reason)
+			reload(cfg)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				debounce.Stop()
+				return
+			case <-hup:
+				apply("SIGHUP")
+			case ev, ok := <-fw.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) != path || ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				debounce.Reset(reloadDelay)
+			case <-debounce.C:
+				apply("file changed")
+			case err, ok := <-fw.Errors:
+				if !ok {
+					return
+				}
+				log.Println("config watcher error: ", err)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,116 @@
+package discord
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestDiffWatchers(t *testing.T) {
+	a := Watcher{Pair: "A/B", DiscordToken: "a"}
+	b := Watcher{Pair: "C/D", DiscordToken: "b"}
+	changed := b
+	changed.Decimals = 4
+	added, removed := DiffWatchers([]Watcher{a, b}, []Watcher{a, changed})
+	require.Equal(t, []Watcher{changed}, added)
+	require.Equal(t, []Watcher{b}, removed)
+	added, removed = DiffWatchers([]Watcher{a}, []Watcher{a})
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
+
+func TestValidate(t *testing.T) {
+	cfg := *ExampleConfig
+	require.NoError(t, cfg.Validate())
+	cfg.Watchers = append([]Watcher{}, ExampleConfig.Watchers...)
+	cfg.Watchers = append(cfg.Watchers, cfg.Watchers[0])
+	require.Error(t, cfg.Validate())
+	cfg.Watchers = []Watcher{ExampleConfig.Watchers[0]}
+	cfg.Watchers[0].Token0Address = "0xnope"
+	require.Error(t, cfg.Validate())
+	cfg.Watchers = []Watcher{ExampleConfig.Watchers[0]}
+	cfg.Watchers[0].Source = "chainlink"
+	require.Error(t, cfg.Validate())
+	cfg.Watchers[0].Source = "oracle"
+	require.Error(t, cfg.Validate())
+	cfg.Watchers = []Watcher{ExampleConfig.Watchers[0]}
+	cfg.Watchers[0].Network = "nowhere"
+	require.Error(t, cfg.Validate())
+}
+
+func TestWatchConfig(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("test-reload.yml")
+	})
+	require.NoError(t, NewConfig("test-reload.yml"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads := make(chan *Config, 1)
+	// the loader's layers over the file are kept on reload
+	load := func() (*Config, error) {
+		cfg, err := LoadConfig("test-reload.yml")
+		if err == nil {
+			cfg.ETHRPCEndpoint = "http://localhost:8545"
+		}
+		return cfg, err
+	}
+	require.NoError(t, WatchConfig(ctx, "test-reload.yml", load, func(cfg *Config) {
+		reloads <- cfg
+	}))
+	write := func(cfg Config) {
+		data, err := yaml.Marshal(cfg)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile("test-reload.yml", data, os.ModePerm))
+	}
+
+	// invalid configs are rejected
+	invalid := *ExampleConfig
+	invalid.Watchers = []Watcher{{Pair: "A/B", Token0Address: "0xnope"}}
+	write(invalid)
+	select {
+	case <-reloads:
+		t.Fatal("invalid config was reloaded")
+	case <-time.After(reloadDelay * 4):
+	}
+
+	valid := *ExampleConfig
+	valid.Watchers = append([]Watcher{}, ExampleConfig.Watchers...)
+	valid.Watchers[0].Decimals = 4
+	valid.Watchers[0].DiscordToken = "token"
+	write(valid)
+	select {
+	case cfg := <-reloads:
+		require.Equal(t, 4, cfg.Watchers[0].Decimals)
+		require.Equal(t, "http://localhost:8545", cfg.ETHRPCEndpoint)
+	case <-time.After(time.Second * 5):
+		t.Fatal("config wasn't reloaded")
+	}
+}
+
+func TestAddSession(t *testing.T) {
+	a := Watcher{Pair: "A/B", DiscordToken: "a"}
+	b := Watcher{Pair: "C/D", DiscordToken: "b"}
+	cfg := *ExampleConfig
+	cfg.Watchers = []Watcher{a, b}
+	client := &Client{cfg: &cfg, sessions: make(map[Watcher]*discordgo.Session)}
+	session := func() *discordgo.Session {
+		dg, err := discordgo.New("Bot token")
+		require.NoError(t, err)
+		return dg
+	}
+	// sessions of configured watchers are kept alongside the other watchers
+	require.True(t, client.addSession(a, session()))
+	require.True(t, client.addSession(b, session()))
+	require.Len(t, client.sessions, 2)
+	// sessions of watchers removed while opening are closed
+	changed := b
+	changed.Decimals = 4
+	require.False(t, client.addSession(changed, session()))
+	require.Len(t, client.sessions, 2)
+}
@@ -0,0 +1,382 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/network"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/common"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Problem is an issue found while validating a config
+type Problem struct {
+	// Path is the location of the offending field, ie watchers[0].token0_address
+	Path string
+	// Line is the line of the field in the config file, 0 if unknown
+	Line int
+	Err  error
+}
+
+func (p Problem) Error() string {
+	switch {
+	case p.Line > 0:
+		return fmt.Sprintf("line %d: %s: %s", p.Line, p.Path, p.Err)
+	case p.Path != "":
+		return fmt.Sprintf("%s: %s", p.Path, p.Err)
+	default:
+		return p.Err.Error()
+	}
+}
+
+func (p Problem) Unwrap() error { return p.Err }
+
+// problems collects the problems found while validating a config
+type problems []Problem
+
+func (p *problems) add(path, format string, args ...interface{}) {
+	*p = append(*p, Problem{Path: path, Err: fmt.Errorf(format, args...)})
+}
+
+// address checks the address is valid and, if it is in mixed case, that its EIP-55 checksum matches.
+// ENS names are accepted, they are checked when resolved by ResolveNames
+func (p *problems) address(path, address string) {
+	switch {
+	case utils.IsENSName(address):
+	case !utils.IsValidAddress(address):
+		p.add(path, "invalid address %q", address)
+	case !utils.IsChecksumAddress(address):
+		p.add(path, "invalid EIP-55 checksum, expected %s", common.HexToAddress(address).Hex())
+	}
+}
+
+// required checks the value isn't empty
+func (p *problems) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		p.add(path, "missing value")
+	}
+}
+
+// Validate checks the config can be used to run the bot, returning the first problem found
+func (c *Config) Validate() error {
+	if problems := c.Problems(); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// Problems returns every problem found with the config without connecting to the chain
+func (c *Config) Problems() []Problem {
+	var p problems
+	if _, err := network.Lookup(c.Network); err != nil {
+		p.add("network", "%s", err)
+	}
+	for i, endpoint := range c.Networks {
+		if _, err := network.Lookup(endpoint.Name); err != nil {
+			p.add(fmt.Sprintf("networks[%d].name", i), "%s", err)
+		}
+		p.required(fmt.Sprintf("networks[%d].rpc", i), endpoint.RPC)
+	}
+	for i, anchor := range c.USDAnchors {
+		p.address(fmt.Sprintf("usd_anchors[%d]", i), anchor)
+	}
+	c.watcherProblems(&p)
+	c.Database.problems(&p)
+	if c.UpdateInterval < 0 {
+		p.add("update_interval", "must not be negative")
+	}
+	if c.UpdateWorkers < 0 {
+		p.add("update_workers", "must not be negative")
+	}
+	if c.StaleAfter < 0 {
+		p.add("stale_after", "must not be negative")
+	}
+	if c.UpdateInterval >= c.staleAfter() {
+		p.add("update_interval", "must be shorter than stale_after (%s)", c.staleAfter())
+	}
+	if c.Confirmations < 0 {
+		p.add("confirmations", "must not be negative")
+	}
+	if c.DepthInterval < 0 {
+		p.add("depth_interval", "must not be negative")
+	}
+
+	for i, pair := range c.WhaleWatch.Pairs {
+		path := fmt.Sprintf("whale_watch.pairs[%d]", i)
+		p.address(path+".token0_address", pair.Token0Address)
+		p.address(path+".token1_address", pair.Token1Address)
+		if pair.ThresholdUSD < 0 {
+			p.add(path+".threshold_usd", "must not be negative")
+		}
+	}
+	if c.WhaleWatch.Enabled {
+		p.required("whale_watch.discord_token", c.WhaleWatch.DiscordToken)
+		p.required("whale_watch.channel_id", c.WhaleWatch.ChannelID)
+	}
+
+	for i, token := range c.Discovery.Tokens {
+		p.address(fmt.Sprintf("discovery.tokens[%d]", i), token)
+	}
+	if c.Discovery.Enabled {
+		p.required("discovery.discord_token", c.Discovery.DiscordToken)
+		p.required("discovery.channel_id", c.Discovery.ChannelID)
+		// without tokens the backfill would request every pair the factory ever created
+		if len(c.Discovery.Tokens) == 0 {
+			p.add("discovery.tokens", "at least one token is required")
+		}
+	}
+
+	// venues are compared on the config's network
+	if net, err := network.Lookup(c.Network); err == nil {
+		for i, name := range c.Arbitrage.Venues {
+			if _, ok := net.LookupDEX(name); !ok {
+				p.add(fmt.Sprintf("arbitrage.venues[%d]", i), "unknown venue %s on %s", name, net.Name)
+			}
+		}
+		if len(c.Arbitrage.Venues) == 1 {
+			p.add("arbitrage.venues", "arbitrage requires at least two venues")
+		} else if c.Arbitrage.Enabled && len(c.Arbitrage.Venues) == 0 && len(net.DEXes) < 2 {
+			p.add("arbitrage.enabled", "arbitrage requires at least two venues but %s has %d", net.Name, len(net.DEXes))
+		}
+	}
+	if c.Arbitrage.DiscordToken != "" {
+		p.required("arbitrage.channel_id", c.Arbitrage.ChannelID)
+	}
+
+	if c.Gas.Interval < 0 {
+		p.add("gas.interval", "must not be negative")
+	}
+	if c.Gas.DiscordToken != "" {
+		for i, watcher := range c.Watchers {
+			if watcher.DiscordToken == c.Gas.DiscordToken {
+				p.add("gas.discord_token", "discord token already used by watchers[%d]", i)
+			}
+		}
+	}
+	return p
+}
+
+// watcherProblems checks each watcher, and that no two watchers price the same pair the same way
+// or share a discord token
+func (c *Config) watcherProblems(p *problems) {
+	pairs := make(map[string]int, len(c.Watchers))
+	tokens := make(map[string]int, len(c.Watchers))
+	for i, watcher := range c.Watchers {
+		path := fmt.Sprintf("watchers[%d]", i)
+		p.required(path+".discord_token", watcher.DiscordToken)
+		p.required(path+".pair", watcher.Pair)
+		p.address(path+".token0_address", watcher.Token0Address)
+		p.address(path+".token1_address", watcher.Token1Address)
+		if strings.EqualFold(watcher.Token0Address, watcher.Token1Address) {
+			p.add(path+".token1_address", "token0 and token1 are the same")
+		}
+		switch strings.ToLower(watcher.Source) {
+		case "", "uniswap":
+		case "uniswap-v3":
+			switch watcher.FeeTier {
+			case 0, 100, 500, 3000, 10000:
+			default:
+				p.add(path+".fee_tier", "unsupported fee tier %d", watcher.FeeTier)
+			}
+		case "chainlink", "median":
+			p.address(path+".chainlink_feed", watcher.ChainlinkFeed)
+		default:
+			p.add(path+".source", "unsupported price source %s", watcher.Source)
+		}
+		if watcher.MaxDeviation < 0 {
+			p.add(path+".max_deviation", "must not be negative")
+		}
+		if watcher.Interval < 0 {
+			p.add(path+".interval", "must not be negative")
+		} else if watcher.Interval >= c.staleAfter() {
+			p.add(path+".interval", "must be shorter than stale_after (%s)", c.staleAfter())
+		}
+		var chainID uint64
+		if net, err := c.WatcherNetwork(watcher); err == nil {
+			chainID = net.ChainID
+		} else if watcher.Network != "" {
+			p.add(path+".network", "%s", err)
+		}
+		key := fmt.Sprintf("%d/%s/%s/%s/%d", chainID, strings.ToLower(watcher.Token0Address),
+			strings.ToLower(watcher.Token1Address), strings.ToLower(watcher.Source), watcher.FeeTier)
+		if j, ok := pairs[key]; ok {
+			p.add(path, "duplicate of watchers[%d]", j)
+		} else {
+			pairs[key] = i
+		}
+		if watcher.DiscordToken == "" {
+			continue
+		}
+		if j, ok := tokens[watcher.DiscordToken]; ok {
+			p.add(path+".discord_token", "discord token already used by watchers[%d]", j)
+		} else {
+			tokens[watcher.DiscordToken] = i
+		}
+	}
+}
+
+// problems checks the settings needed by the database type are present
+func (d Database) problems(p *problems) {
+	switch strings.ToLower(d.Type) {
+	case "sqlite":
+		p.required("database.db_name", d.DBName)
+	case "postgres":
+		p.required("database.host", d.Host)
+		p.required("database.user", d.User)
+		p.required("database.db_name", d.DBName)
+		if port, err := strconv.Atoi(d.Port); err != nil || port <= 0 || port > 65535 {
+			p.add("database.port", "invalid port %q", d.Port)
+		}
+	default:
+		p.add("database.type", "unsupported db type %q, must be sqlite or postgres", d.Type)
+	}
+}
+
+// ResolveNames replaces the ENS names used in place of addresses with the addresses they resolve
+// to, returning a problem for each name that doesn't resolve. Names are resolved on the client's
+// network, so watchers on other networks must use hex addresses
+func (c *Config) ResolveNames(bc *bclient.Client) []Problem {
+	var p problems
+	resolve := func(path string, address *string) {
+		if !utils.IsENSName(*address) {
+			return
+		}
+		resolved, err := bc.ResolveName(*address)
+		if err != nil {
+			p.add(path, "%s", err)
+			return
+		}
+		*address = resolved.Hex()
+	}
+	for i := range c.USDAnchors {
+		resolve(fmt.Sprintf("usd_anchors[%d]", i), &c.USDAnchors[i])
+	}
+	for i := range c.Watchers {
+		path := fmt.Sprintf("watchers[%d]", i)
+		watcher := &c.Watchers[i]
+		if net, err := c.WatcherNetwork(*watcher); err == nil && net.ChainID != bc.Network().ChainID {
+			for _, field := range []struct{ name, address string }{
+				{".token0_address", watcher.Token0Address},
+				{".token1_address", watcher.Token1Address},
+				{".chainlink_feed", watcher.ChainlinkFeed},
+			} {
+				if utils.IsENSName(field.address) {
+					p.add(path+field.name, "ens names are only resolved on %s", bc.Network().Name)
+				}
+			}
+			continue
+		}
+		resolve(path+".token0_address", &watcher.Token0Address)
+		resolve(path+".token1_address", &watcher.Token1Address)
+		resolve(path+".chainlink_feed", &watcher.ChainlinkFeed)
+	}
+	for i := range c.WhaleWatch.Pairs {
+		path := fmt.Sprintf("whale_watch.pairs[%d]", i)
+		resolve(path+".token0_address", &c.WhaleWatch.Pairs[i].Token0Address)
+		resolve(path+".token1_address", &c.WhaleWatch.Pairs[i].Token1Address)
+	}
+	for i := range c.Discovery.Tokens {
+		resolve(fmt.Sprintf("discovery.tokens[%d]", i), &c.Discovery.Tokens[i])
+	}
+	return p
+}
+
+// ChainProblems checks the watchers against the chain, using the client for each watcher's network.
+// The tokens must exist, decimals if set must match the quote token's, and the pairs of uniswap
+// priced watchers must exist on the network's exchange
+func (c *Config) ChainProblems(clients ...*bclient.Client) []Problem {
+	var p problems
+	for i, watcher := range c.Watchers {
+		path := fmt.Sprintf("watchers[%d]", i)
+		net, err := c.WatcherNetwork(watcher)
+		if err != nil {
+			continue
+		}
+		var bc *bclient.Client
+		for _, client := range clients {
+			if client.Network().ChainID == net.ChainID {
+				bc = client
+				break
+			}
+		}
+		if bc == nil {
+			p.add(path+".network", "not connected to %s", net.Name)
+			continue
+		}
+		token0, err := bc.TokenInfo(common.HexToAddress(watcher.Token0Address))
+		if err != nil {
+			p.add(path+".token0_address", "failed to read token: %s", err)
+		}
+		token1, err := bc.TokenInfo(common.HexToAddress(watcher.Token1Address))
+		if err != nil {
+			p.add(path+".token1_address", "failed to read token: %s", err)
+		}
+		if token0 == nil || token1 == nil {
+			continue
+		}
+		if watcher.Decimals != 0 && watcher.Decimals != token1.Decimals {
+			p.add(path+".decimals", "%d does not match the %d decimals of %s", watcher.Decimals, token1.Decimals, token1.Symbol)
+		}
+		switch strings.ToLower(watcher.Source) {
+		case "", "uniswap", "median":
+		default:
+			continue
+		}
+		if _, err := bc.Reserves(watcher.Token0Address, watcher.Token1Address); err != nil {
+			if errors.Is(err, uniswap.ErrPairNotFound) {
+				p.add(path, "no %s/%s pair on %s", token0.Symbol, token1.Symbol, bc.Uniswap().Venue().Name)
+			} else {
+				p.add(path, "failed to read reserves: %s", err)
+			}
+		}
+	}
+	return p
+}
+
+// Locate sets the line numbers of the problems from the YAML source of the config
+func Locate(data []byte, problems []Problem) {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &root); err != nil {
+		return
+	}
+	for i := range problems {
+		problems[i].Line = locate(&root, problems[i].Path)
+	}
+}
+
+// locate returns the line of the deepest node along the path, or 0 if the path is empty
+func locate(node *yamlv3.Node, path string) int {
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	var line int
+	keys := strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' || r == ']' })
+	for _, key := range keys {
+		var next *yamlv3.Node
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					// the key is used as nested values start on the following line
+					line, next = node.Content[i].Line, node.Content[i+1]
+					break
+				}
+			}
+		case yamlv3.SequenceNode:
+			if i, err := strconv.Atoi(key); err == nil && i < len(node.Content) {
+				next = node.Content[i]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
@@ -0,0 +1,147 @@
+package discord
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestProblems(t *testing.T) {
+	weth, dai := bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String()
+	watcher := Watcher{DiscordToken: "token", Token0Address: weth, Token1Address: dai, Pair: "WETH/DAI"}
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		paths  []string
+	}{
+		{"valid", func(cfg *Config) {}, nil},
+		{"lowercase address", func(cfg *Config) {
+			cfg.Watchers[0].Token0Address = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+		}, nil},
+		{"bad checksum", func(cfg *Config) {
+			cfg.Watchers[0].Token0Address = "0xC02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+		}, []string{"watchers[0].token0_address"}},
+		{"malformed address", func(cfg *Config) { cfg.USDAnchors = []string{"0x1234"} }, []string{"usd_anchors[0]"}},
+		{"ens name", func(cfg *Config) { cfg.Watchers[0].Token0Address = "weth.eth" }, nil},
+		{"missing token", func(cfg *Config) { cfg.Watchers[0].DiscordToken = "" }, []string{"watchers[0].discord_token"}},
+		{"duplicate watcher", func(cfg *Config) {
+			dup := watcher
+			dup.DiscordToken = "other"
+			dup.Pair = "ETH/DAI"
+			cfg.Watchers = append(cfg.Watchers, dup)
+		}, []string{"watchers[1]"}},
+		{"shared token", func(cfg *Config) {
+			other := watcher
+			other.Source = "uniswap-v3"
+			cfg.Watchers = append(cfg.Watchers, other)
+		}, []string{"watchers[1].discord_token"}},
+		{"unknown source", func(cfg *Config) { cfg.Watchers[0].Source = "oracle" }, []string{"watchers[0].source"}},
+		{"unknown db type", func(cfg *Config) { cfg.Database.Type = "mysql" }, []string{"database.type"}},
+		{"postgres", func(cfg *Config) {
+			cfg.Database = Database{Type: "postgres", Host: "localhost", Port: "5432", User: "user", DBName: "indexed"}
+		}, nil},
+		{"postgres port", func(cfg *Config) {
+			cfg.Database = Database{Type: "postgres", Host: "localhost", Port: "pg", User: "user", DBName: "indexed"}
+		}, []string{"database.port"}},
+		{"unknown venue", func(cfg *Config) { cfg.Arbitrage.Venues = []string{"uniswap", "curve"} }, []string{"arbitrage.venues[1]"}},
+		{"venue on another network", func(cfg *Config) {
+			cfg.Network = "polygon"
+			cfg.Arbitrage.Venues = []string{"quickswap", "sushiswap"}
+		}, []string{"arbitrage.venues[1]"}},
+		{"network without venues", func(cfg *Config) {
+			cfg.Network = "polygon"
+			cfg.Arbitrage.Enabled = true
+		}, []string{"arbitrage.enabled"}},
+		{"discovery without tokens", func(cfg *Config) {
+			cfg.Discovery = Discovery{Enabled: true, DiscordToken: "token", ChannelID: "channel"}
+		}, []string{"discovery.tokens"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Network:  "mainnet",
+				Watchers: []Watcher{watcher},
+				Database: Database{Type: "sqlite", DBName: "indexed"},
+			}
+			tt.modify(cfg)
+			var paths []string
+			for _, problem := range cfg.Problems() {
+				paths = append(paths, problem.Path)
+			}
+			require.Equal(t, tt.paths, paths)
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	cfg := *ExampleConfig
+	cfg.Database.Type = "mysql"
+	cfg.Watchers = append([]Watcher{}, ExampleConfig.Watchers...)
+	cfg.Watchers[0].Token1Address = "0xnope"
+	data, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	problems := cfg.Problems()
+	require.Len(t, problems, 2)
+	Locate(data, problems)
+	var lines []string
+	for _, problem := range problems {
+		require.NotZero(t, problem.Line)
+		lines = append(lines, string(bytes.Split(data, []byte("\n"))[problem.Line-1]))
+	}
+	require.Equal(t, []string{"  token1_address: 0xnope", "  type: mysql"}, lines)
+	require.Contains(t, problems[0].Error(), "line 12: watchers[0].token1_address")
+}
+
+func TestChainProblems(t *testing.T) {
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	bc := h.Client()
+	defer bc.Close()
+	_, err = h.SeedPools(harness.Pool{
+		TokenA:  bclient.WETHTokenAddress,
+		TokenB:  bclient.USDCTokenAddress,
+		AmountA: utils.ToWei(int64(100), 18),
+		AmountB: utils.ToWei(int64(200000), 6),
+	})
+	require.NoError(t, err)
+	weth, usdc, dai := bclient.WETHTokenAddress.String(), bclient.USDCTokenAddress.String(), bclient.DAITokenAddress.String()
+	cfg := &Config{Network: "mainnet", Watchers: []Watcher{
+		{Token0Address: weth, Token1Address: usdc},
+		{Token0Address: weth, Token1Address: dai},
+		{Token0Address: weth, Token1Address: "0x1111111111111111111111111111111111111111"},
+		{Token0Address: weth, Token1Address: usdc, Network: "polygon"},
+		{Token0Address: weth, Token1Address: usdc, Decimals: 6},
+		{Token0Address: weth, Token1Address: usdc, Decimals: 18},
+	}}
+	var paths []string
+	for _, problem := range cfg.ChainProblems(bc) {
+		paths = append(paths, problem.Path)
+	}
+	require.Equal(t, []string{"watchers[1]", "watchers[2].token1_address", "watchers[3].network", "watchers[5].decimals"}, paths)
+}
+
+func TestResolveNames(t *testing.T) {
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	bc := h.Client()
+	defer bc.Close()
+	weth, usdc := bclient.WETHTokenAddress.String(), bclient.USDCTokenAddress.String()
+	cfg := &Config{Network: "mainnet", Watchers: []Watcher{
+		{Token0Address: weth, Token1Address: usdc},
+		// the simulated chain has no ENS registry so names don't resolve
+		{Token0Address: weth, Token1Address: "usdc.eth"},
+		{Token0Address: "wmatic.eth", Token1Address: usdc, Network: "polygon"},
+	}}
+	var paths []string
+	for _, problem := range cfg.ResolveNames(bc) {
+		paths = append(paths, problem.Path)
+	}
+	require.Equal(t, []string{"watchers[1].token1_address", "watchers[2].token0_address"}, paths)
+	require.Equal(t, weth, cfg.Watchers[0].Token0Address)
+	require.Equal(t, "usdc.eth", cfg.Watchers[1].Token1Address)
+}
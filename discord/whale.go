@@ -0,0 +1,269 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// WhaleWatcher posts swaps on watched pairs that are worth more than
+// the configured USD threshold to a discord channel
+type WhaleWatcher struct {
+	s   *discordgo.Session
+	bc  *bclient.Client
+	db  *db.Database
+	cfg WhaleWatch
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// NewWhaleWatcher opens the discord session used to post whale swaps
+func NewWhaleWatcher(ctx context.Context, cfg WhaleWatch, bc *bclient.Client, db *db.Database) (*WhaleWatcher, error) {
+	dg, err := discordgo.New("Bot " + cfg.DiscordToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := dg.Open(); err != nil {
+		return nil, err
+	}
+	if cfg.ExplorerURL == "" {
+		cfg.ExplorerURL = "https://etherscan.io"
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &WhaleWatcher{
+		s:      dg,
+		bc:     bc,
+		db:     db,
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		wg:     &sync.WaitGroup{},
+	}, nil
+}
+
+// Start begins watching swaps on all configured pairs
+func (w *WhaleWatcher) Start() {
+	for _, pair := range w.cfg.Pairs {
+		w.wg.Add(1)
+		go func(pair WhalePair) {
+			defer w.wg.Done()
+			w.watchPair(pair)
+		}(pair)
+	}
+}
+
+// Close stops watching swaps and terminates the discord session
+func (w *WhaleWatcher) Close() error {
+	w.cancel()
+	w.wg.Wait()
+	return w.s.Close()
+}
+
+func (w *WhaleWatcher) watchPair(pair WhalePair) {
+	token0 := common.HexToAddress(pair.Token0Address)
+	token1 := common.HexToAddress(pair.Token1Address)
+	pairAddr, err := w.bc.Uniswap().PairAddress(token0, token1)
+	if err != nil {
+		log.Printf("failed to resolve pair for token0: %s token1: %s - %s\n", pair.Token0Address, pair.Token1Address, err)
+		return
+	}
+	// lastBlock is the block of the most recently handled swap, and is
+	// where we start backfilling from whenever the subscription is re-established
+	var lastBlock uint64
+	if block, err := w.db.LastSwapBlock(pairAddr.String()); err == nil {
+		lastBlock = block
+	} else if block, err := w.bc.CurrentBlock(); err == nil {
+		lastBlock = block
+	}
+	sink := make(chan *uniswap.Swap)
+	sub := event.Resubscribe(time.Minute, func(ctx context.Context) (event.Subscription, error) {
+		sub, err := w.bc.Uniswap().WatchSwaps(ctx, token0, token1, sink)
+		if err != nil {
+			log.Printf("failed to subscribe to swaps for pair %s: %s\n", pairAddr, err)
+			return nil, err
+		}
+		// swaps emitted while we were disconnected are backfilled, from the earliest swap that
+		// failed to be posted if any. Swaps that were already posted are skipped when recording them
+		from := atomic.LoadUint64(&lastBlock)
+		if block, err := w.db.PendingSwapBlock(pairAddr.String()); err == nil && block < from {
+			from = block
+		}
+		swaps, err := w.bc.Uniswap().FilterSwaps(ctx, token0, token1, from, nil)
+		if err != nil {
+			log.Printf("failed to backfill swaps for pair %s: %s\n", pairAddr, err)
+		}
+		for _, swap := range swaps {
+			select {
+			case sink <- swap:
+			case <-ctx.Done():
+				return sub, nil
+			}
+		}
+		return sub, nil
+	})
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case swap := <-sink:
+			if err := w.handleSwap(pair, token0, token1, swap); err != nil {
+				log.Printf("failed to handle swap %s on pair %s: %s\n", swap.TxHash, pairAddr, err)
+				continue
+			}
+			if swap.BlockNumber > atomic.LoadUint64(&lastBlock) {
+				atomic.StoreUint64(&lastBlock, swap.BlockNumber)
+			}
+		}
+	}
+}
+
+func (w *WhaleWatcher) handleSwap(pair WhalePair, token0, token1 common.Address, swap *uniswap.Swap) error {
+	if swap.Removed {
+		// the swap's block was reorganized out of the chain, if the swap is mined again it is
+		// delivered again from its new block
+		return w.db.RemoveSwap(swap.TxHash.String(), swap.LogIndex)
+	}
+	tkn0, err := w.bc.TokenInfo(token0)
+	if err != nil {
+		return err
+	}
+	tkn1, err := w.bc.TokenInfo(token1)
+	if err != nil {
+		return err
+	}
+	amount0 := new(big.Int).Add(swap.Amount0In, swap.Amount0Out)
+	amount1 := new(big.Int).Add(swap.Amount1In, swap.Amount1Out)
+	// value the swap using the quote token first as it is usually WETH or a stablecoin
+	usdValue, err := w.bc.USDValue(token1, amount1)
+	if err != nil {
+		if usdValue, err = w.bc.USDValue(token0, amount0); err != nil {
+			return err
+		}
+	}
+	trader, err := w.bc.TxSender(swap.TxHash, swap.BlockHash, swap.TxIndex)
+	if err != nil {
+		// not fatal, fallback to the swap recipient
+		trader = swap.To
+	}
+	// not fatal either, the trader is shown without a name
+	traderName, _ := w.bc.LookupAddress(trader)
+	amount0F, _ := utils.ToDecimal(amount0, tkn0.Decimals).Float64()
+	amount1F, _ := utils.ToDecimal(amount1, tkn1.Decimals).Float64()
+	usdValueF, _ := utils.ToDecimal(usdValue, 18).Float64()
+	isNew, err := w.db.RecordSwap(&db.Swap{
+		TxHash:      swap.TxHash.String(),
+		LogIndex:    swap.LogIndex,
+		ChainID:     w.bc.Network().ChainID,
+		BlockNumber: swap.BlockNumber,
+		BlockHash:   swap.BlockHash.String(),
+		Pair:        swap.Pair.String(),
+		Token0:      token0.String(),
+		Token1:      token1.String(),
+		Trader:      trader.String(),
+		Buy:         swap.IsBuy(),
+		Amount0:     amount0F,
+		Amount1:     amount1F,
+		USDValue:    usdValueF,
+		Pending:     usdValueF >= pair.ThresholdUSD,
+	})
+	if err != nil {
+		return err
+	}
+	if usdValueF < pair.ThresholdUSD {
+		return nil
+	}
+	// swaps seen again are only posted if posting them failed before
+	if !isNew {
+		pending, err := w.db.SwapPending(swap.TxHash.String(), swap.LogIndex)
+		if err != nil || !pending {
+			return err
+		}
+	}
+	// price impact is calculated against the reserves prior to the block containing the swap,
+	// including the fee charged by the venue the pair is on
+	var impact float64
+	fee := w.bc.Uniswap().Venue().Fee
+	reserves, err := w.bc.Uniswap().GetReservesAt(token0, token1, new(big.Int).SetUint64(swap.BlockNumber-1))
+	if err == nil {
+		if swap.IsBuy() {
+			impact = uniswap.PriceImpactWithFee(swap.Amount1In, reserves.Reserve1, reserves.Reserve0, fee)
+		} else {
+			impact = uniswap.PriceImpactWithFee(swap.Amount0In, reserves.Reserve0, reserves.Reserve1, fee)
+		}
+	}
+	channelID := pair.ChannelID
+	if channelID == "" {
+		channelID = w.cfg.ChannelID
+	}
+	if _, err := w.s.ChannelMessageSendEmbed(channelID, renderWhaleEmbed(w.cfg.ExplorerURL, tkn0, tkn1, swap, amount0F, amount1F, usdValueF, impact, trader, traderName)); err != nil {
+		return err
+	}
+	return w.db.MarkSwapPosted(swap.TxHash.String(), swap.LogIndex)
+}
+
+// renderWhaleEmbed renders the embed announcing a whale swap, showing the trader's ENS name if it has one
+func renderWhaleEmbed(explorerURL string, tkn0, tkn1 *bclient.Token, swap *uniswap.Swap, amount0, amount1, usdValue, impact float64, trader common.Address, traderName string) *discordgo.MessageEmbed {
+	direction, color := "SELL", 0xff0000
+	if swap.IsBuy() {
+		direction, color = "BUY", 0x00ff00
+	}
+	return &discordgo.MessageEmbed{
+		Type:      "rich",
+		Title:     fmt.Sprintf("Whale %s %s", direction, tkn0.Symbol),
+		URL:       explorerURL + "/tx/" + swap.TxHash.String(),
+		Timestamp: time.Now().Format(time.RFC3339),
+		Color:     color,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Direction",
+				Value:  direction,
+				Inline: true,
+			},
+			{
+				Name:   tkn0.Symbol,
+				Value:  fmt.Sprintf("%.4f", amount0),
+				Inline: true,
+			},
+			{
+				Name:   tkn1.Symbol,
+				Value:  fmt.Sprintf("%.4f", amount1),
+				Inline: true,
+			},
+			{
+				Name:   "USD Value",
+				Value:  fmt.Sprintf("$%.2f", usdValue),
+				Inline: true,
+			},
+			{
+				Name:   "Price Impact",
+				Value:  fmt.Sprintf("%.2f%%", impact*100),
+				Inline: true,
+			},
+			{
+				Name:   "Trader",
+				Value:  fmt.Sprintf("[%s](%s/address/%s)", displayAddress(trader, traderName), explorerURL, trader.String()),
+				Inline: false,
+			},
+			{
+				Name:   "Transaction",
+				Value:  fmt.Sprintf("[%s](%s/tx/%s)", swap.TxHash.String(), explorerURL, swap.TxHash.String()),
+				Inline: false,
+			},
+		},
+	}
+}
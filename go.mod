@@ -6,12 +6,14 @@ require (
 	github.com/bonedaddy/dgc v0.0.2-0.20210101001021-5e0add7c4088
 	github.com/bwmarrin/discordgo v0.22.1-0.20201217190221-8d6815dde7ed
 	github.com/ethereum/go-ethereum v1.9.25
+	github.com/fsnotify/fsnotify v1.4.9
 	github.com/shopspring/decimal v1.2.0
 	github.com/stretchr/testify v1.6.1
 	github.com/urfave/cli/v2 v2.3.0
 	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/net v0.0.0-20201224014010-6772e930b67b // indirect
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 	gorm.io/driver/postgres v1.0.6
 	gorm.io/driver/sqlite v1.1.4
 	gorm.io/gorm v1.20.9
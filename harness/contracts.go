@@ -0,0 +1,65 @@
+package harness
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+)
+
+// initCode returns the init code with the abi encoding of the constructor arguments appended.
+// Arguments may be addresses, strings and uint8s
+func initCode(bin string, args ...interface{}) ([]byte, error) {
+	var arguments abi.Arguments
+	for _, arg := range args {
+		var name string
+		switch arg.(type) {
+		case common.Address:
+			name = "address"
+		case string:
+			name = "string"
+		case uint8:
+			name = "uint8"
+		default:
+			return nil, fmt.Errorf("unsupported constructor argument %T", arg)
+		}
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, abi.Argument{Type: typ})
+	}
+	packed, err := arguments.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+	return append(common.FromHex(bin), packed...), nil
+}
+
+// install runs the init code in a scratch state and returns the contract it creates as a genesis
+// account, so contracts may be placed at fixed addresses in the genesis block
+func install(code []byte) (core.GenesisAccount, error) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return core.GenesisAccount{}, err
+	}
+	runtimeCode, address, _, err := runtime.Create(code, &runtime.Config{State: statedb})
+	if err != nil {
+		return core.GenesisAccount{}, err
+	}
+	// storage is only iterable once committed to the storage trie
+	if _, err := statedb.Commit(true); err != nil {
+		return core.GenesisAccount{}, err
+	}
+	storage := make(map[common.Hash]common.Hash)
+	err = statedb.ForEachStorage(address, func(key, value common.Hash) bool {
+		storage[key] = value
+		return true
+	})
+	return core.GenesisAccount{Code: runtimeCode, Storage: storage, Balance: new(big.Int)}, err
+}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IERC20 {
+    function transfer(address to, uint256 value) external returns (bool);
+}
+
+// Callee is a flash swap callee that repays the pair from its own balance. The callback data is
+// the abi encoding of (address token, uint256 amount), and the callee transfers amount of token to
+// the pair, so it must hold enough of token to cover the fee
+contract Callee {
+    function uniswapV2Call(address, uint256, uint256, bytes calldata data) external {
+        (address token, uint256 amount) = abi.decode(data, (address, uint256));
+        require(IERC20(token).transfer(msg.sender, amount), "Callee: repayment failed");
+    }
+}
@@ -0,0 +1,278 @@
+// Package harness provides an in memory ethereum chain running the uniswap v2 contracts,
+// allowing unibot to be tested end to end without network access
+package harness
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bonedaddy/unibot/backend"
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/bindings/erc20"
+	uniswapv2factory "github.com/bonedaddy/unibot/bindings/uniswapv2/factory"
+	uniswapv2pair "github.com/bonedaddy/unibot/bindings/uniswapv2/pair"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mintABI is the ABI of the mint function of the harness tokens, which isn't part of the ERC20 binding
+const mintABI = `[{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"mint","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+// Token is a token placed at a fixed address in the genesis block
+type Token struct {
+	Address  common.Address
+	Name     string
+	Symbol   string
+	Decimals int
+}
+
+// MainnetTokens are the tokens unibot refers to by their mainnet addresses, such as WETH and the
+// USD stablecoins, allowing code using the bclient constants to run against the harness
+var MainnetTokens = []Token{
+	{Address: bclient.WETHTokenAddress, Name: "Wrapped Ether", Symbol: "WETH", Decimals: 18},
+	{Address: bclient.DAITokenAddress, Name: "Dai Stablecoin", Symbol: "DAI", Decimals: 18},
+	{Address: bclient.USDCTokenAddress, Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+	{Address: bclient.USDTTokenAddress, Name: "Tether USD", Symbol: "USDT", Decimals: 6},
+	{Address: bclient.DEFI5TokenAddress, Name: "DEFI Top 5 Tokens Index", Symbol: "DEFI5", Decimals: 18},
+	{Address: bclient.CC10TokenAddress, Name: "Cryptocurrency Top 10 Tokens Index", Symbol: "CC10", Decimals: 18},
+	{Address: bclient.NDXTokenAddress, Name: "Indexed", Symbol: "NDX", Decimals: 18},
+}
+
+// Pool is a pair created through the factory and seeded with the given amounts of each token
+type Pool struct {
+	TokenA  common.Address
+	TokenB  common.Address
+	AmountA *big.Int
+	AmountB *big.Int
+}
+
+// Harness is a simulated chain with a funded account used to deploy and interact with contracts
+type Harness struct {
+	Backend *backends.SimulatedBackend
+	Key     *ecdsa.PrivateKey
+	Auth    *bind.TransactOpts
+}
+
+// New returns a harness whose account is funded with 1000 ETH. The uniswap v2 factory and
+// Router02 are placed at uniswap.FactoryAddress and uniswap.Router02Address along with the given
+// tokens in the genesis block, so pairs created with CreatePair are found by the uniswap client.
+// The router's WETH is bclient.WETHTokenAddress. See bytecode.go for the contracts
+func New(tokens ...Token) (*Harness, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	auth := bind.NewKeyedTransactor(key)
+	balance := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	alloc := core.GenesisAlloc{auth.From: {Balance: balance}}
+	contracts := map[common.Address][]byte{}
+	if contracts[uniswap.FactoryAddress], err = initCode(factoryBin, auth.From); err != nil {
+		return nil, err
+	}
+	if contracts[uniswap.Router02Address], err = initCode(router02Bin, uniswap.FactoryAddress, bclient.WETHTokenAddress); err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		if contracts[token.Address], err = initCode(tokenBin, token.Name, token.Symbol, uint8(token.Decimals)); err != nil {
+			return nil, err
+		}
+	}
+	for address, code := range contracts {
+		if alloc[address], err = install(code); err != nil {
+			return nil, fmt.Errorf("failed to install contract at %s: %w", address, err)
+		}
+	}
+	backend := backends.NewSimulatedBackend(alloc, 10000000)
+	return &Harness{Backend: backend, Key: key, Auth: auth}, nil
+}
+
+// Client returns a bclient wired to the simulated chain. Closing the client closes the harness
+func (h *Harness) Client() *bclient.Client {
+	return bclient.NewClientWithBackend(backend.NewSimulated(h.Backend))
+}
+
+// Close shuts down the simulated chain
+func (h *Harness) Close() error {
+	return h.Backend.Close()
+}
+
+// DeployToken deploys a mintable ERC20 token
+func (h *Harness) DeployToken(name, symbol string, decimals int) (common.Address, error) {
+	code, err := initCode(tokenBin, name, symbol, uint8(decimals))
+	if err != nil {
+		return common.Address{}, err
+	}
+	return h.deploy(code)
+}
+
+// DeployPair deploys another uniswap v2 factory and creates a pair for the given tokens through
+// it, so the pair isn't known to the factory at uniswap.FactoryAddress
+func (h *Harness) DeployPair(tokenA, tokenB common.Address) (common.Address, error) {
+	code, err := initCode(factoryBin, h.Auth.From)
+	if err != nil {
+		return common.Address{}, err
+	}
+	factory, err := h.deploy(code)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return h.createPair(factory, tokenA, tokenB)
+}
+
+// CreatePair creates a pair for the given tokens through the factory, returning its address
+func (h *Harness) CreatePair(tokenA, tokenB common.Address) (common.Address, error) {
+	return h.createPair(uniswap.FactoryAddress, tokenA, tokenB)
+}
+
+func (h *Harness) createPair(factory, tokenA, tokenB common.Address) (common.Address, error) {
+	if err := h.Transact(factory, uniswapv2factory.Uniswapv2factoryABI, "createPair", tokenA, tokenB); err != nil {
+		return common.Address{}, err
+	}
+	caller, err := uniswapv2factory.NewUniswapv2factoryCaller(factory, h.Backend)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return caller.GetPair(nil, tokenA, tokenB)
+}
+
+// SeedPools creates each pool through the factory and adds its liquidity, returning the pair addresses
+func (h *Harness) SeedPools(pools ...Pool) ([]common.Address, error) {
+	pairs := make([]common.Address, 0, len(pools))
+	for _, pool := range pools {
+		pair, err := h.CreatePair(pool.TokenA, pool.TokenB)
+		if err != nil {
+			return nil, err
+		}
+		amount0, amount1 := pool.AmountA, pool.AmountB
+		if bytes.Compare(pool.TokenA.Bytes(), pool.TokenB.Bytes()) > 0 {
+			amount0, amount1 = amount1, amount0
+		}
+		if err := h.AddLiquidity(pair, amount0, amount1); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs, nil
+}
+
+// DeployCallee deploys a flash swap callee that repays the pair from its own balance. The
+// callback data must be the abi encoding of (address token, uint256 amount), and the callee
+// transfers amount of token to the pair, so it must hold enough of token to cover the fee
+func (h *Harness) DeployCallee() (common.Address, error) {
+	return h.deploy(common.FromHex(calleeBin))
+}
+
+// Mint mints amount of token to the given address
+func (h *Harness) Mint(token, to common.Address, amount *big.Int) error {
+	return h.Transact(token, mintABI, "mint", to, amount)
+}
+
+// AddLiquidity mints the amounts of each of the pair's tokens to the pair and mints the liquidity
+// tokens to the harness account. The first deposit to a pair must be large enough for the pair to
+// lock its minimum liquidity of 1000
+func (h *Harness) AddLiquidity(pair common.Address, amount0, amount1 *big.Int) error {
+	caller, err := uniswapv2pair.NewUniswapv2pairCaller(pair, h.Backend)
+	if err != nil {
+		return err
+	}
+	token0, err := caller.Token0(nil)
+	if err != nil {
+		return err
+	}
+	token1, err := caller.Token1(nil)
+	if err != nil {
+		return err
+	}
+	if err := h.Mint(token0, pair, amount0); err != nil {
+		return err
+	}
+	if err := h.Mint(token1, pair, amount1); err != nil {
+		return err
+	}
+	return h.Transact(pair, uniswapv2pair.Uniswapv2pairABI, "mint", h.Auth.From)
+}
+
+// BalanceOf returns the token balance of the owner
+func (h *Harness) BalanceOf(token, owner common.Address) (*big.Int, error) {
+	caller, err := erc20.NewErc20Caller(token, h.Backend)
+	if err != nil {
+		return nil, err
+	}
+	return caller.BalanceOf(nil, owner)
+}
+
+// TotalSupply returns the total supply of the token, such as the liquidity tokens of a pair
+func (h *Harness) TotalSupply(token common.Address) (*big.Int, error) {
+	caller, err := erc20.NewErc20Caller(token, h.Backend)
+	if err != nil {
+		return nil, err
+	}
+	return caller.TotalSupply(nil)
+}
+
+// Transact sends a transaction calling method on the contract with the given ABI, mines it and
+// returns an error if it reverted
+func (h *Harness) Transact(contract common.Address, contractABI, method string, args ...interface{}) error {
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return err
+	}
+	tx, err := bind.NewBoundContract(contract, parsed, h.Backend, h.Backend, h.Backend).Transact(h.Auth, method, args...)
+	if err != nil {
+		return err
+	}
+	return h.mine(tx)
+}
+
+// SendTransaction sends a transaction from the harness account with the given calldata, mines it
+// and returns an error if it reverted
+func (h *Harness) SendTransaction(to common.Address, value *big.Int, data []byte) error {
+	tx, err := bind.NewBoundContract(to, abi.ABI{}, h.Backend, h.Backend, h.Backend).RawTransact(
+		&bind.TransactOpts{From: h.Auth.From, Signer: h.Auth.Signer, Value: value}, data,
+	)
+	if err != nil {
+		return err
+	}
+	return h.mine(tx)
+}
+
+func (h *Harness) deploy(code []byte) (common.Address, error) {
+	addr, tx, _, err := bind.DeployContract(h.Auth, abi.ABI{}, code, h.Backend)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return addr, h.mine(tx)
+}
+
+// mine commits the pending block and checks the transaction succeeded
+func (h *Harness) mine(tx *types.Transaction) error {
+	h.Backend.Commit()
+	receipt, err := h.Backend.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s reverted", tx.Hash())
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		code, err := h.Backend.CodeAt(context.Background(), receipt.ContractAddress, nil)
+		if err != nil {
+			return err
+		}
+		if len(code) == 0 {
+			return errors.New("contract deployment returned no code")
+		}
+	}
+	return nil
+}
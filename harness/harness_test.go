@@ -0,0 +1,209 @@
+package harness
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/bindings/erc20"
+	uniswapv2factory "github.com/bonedaddy/unibot/bindings/uniswapv2/factory"
+	uniswapv2pair "github.com/bonedaddy/unibot/bindings/uniswapv2/pair"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+	defer h.Close()
+	token, err := h.DeployToken("Test Token", "TST", 6)
+	require.NoError(t, err)
+	caller, err := erc20.NewErc20Caller(token, h.Backend)
+	require.NoError(t, err)
+	symbol, err := caller.Symbol(nil)
+	require.NoError(t, err)
+	require.Equal(t, "TST", symbol)
+	name, err := caller.Name(nil)
+	require.NoError(t, err)
+	require.Equal(t, "Test Token", name)
+	decimals, err := caller.Decimals(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint8(6), decimals)
+
+	require.NoError(t, h.Mint(token, h.Auth.From, big.NewInt(1000)))
+	supply, err := caller.TotalSupply(nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), supply.Int64())
+
+	recipient := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	require.NoError(t, h.Transact(token, erc20.Erc20ABI, "transfer", recipient, big.NewInt(300)))
+	// transferring more than the balance reverts
+	require.Error(t, h.Transact(token, erc20.Erc20ABI, "transfer", recipient, big.NewInt(701)))
+	balance, err := h.BalanceOf(token, recipient)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), balance.Int64())
+
+	// transferFrom spends the allowance given to the caller
+	require.Error(t, h.Transact(token, erc20.Erc20ABI, "transferFrom", h.Auth.From, recipient, big.NewInt(1)))
+	require.NoError(t, h.Transact(token, erc20.Erc20ABI, "approve", h.Auth.From, big.NewInt(100)))
+	require.NoError(t, h.Transact(token, erc20.Erc20ABI, "transferFrom", h.Auth.From, recipient, big.NewInt(60)))
+	allowance, err := caller.Allowance(nil, h.Auth.From, h.Auth.From)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), allowance.Int64())
+	require.Error(t, h.Transact(token, erc20.Erc20ABI, "transferFrom", h.Auth.From, recipient, big.NewInt(41)))
+	balance, err = h.BalanceOf(token, h.Auth.From)
+	require.NoError(t, err)
+	require.Equal(t, int64(640), balance.Int64())
+}
+
+func TestPair(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+	defer h.Close()
+	tokenA, err := h.DeployToken("Token A", "A", 18)
+	require.NoError(t, err)
+	tokenB, err := h.DeployToken("Token B", "B", 18)
+	require.NoError(t, err)
+	pair, err := h.DeployPair(tokenA, tokenB)
+	require.NoError(t, err)
+	caller, err := uniswapv2pair.NewUniswapv2pairCaller(pair, h.Backend)
+	require.NoError(t, err)
+	token0, err := caller.Token0(nil)
+	require.NoError(t, err)
+	token1, err := caller.Token1(nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Address{tokenA, tokenB}, []common.Address{token0, token1})
+	require.Equal(t, -1, new(big.Int).SetBytes(token0.Bytes()).Cmp(new(big.Int).SetBytes(token1.Bytes())))
+
+	require.NoError(t, h.AddLiquidity(pair, big.NewInt(1000000), big.NewInt(2000000)))
+	reserves, err := caller.GetReserves(nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000000), reserves.Reserve0.Int64())
+	require.Equal(t, int64(2000000), reserves.Reserve1.Int64())
+	// the liquidity tokens are minted to the harness account, less the locked minimum liquidity
+	supply, err := h.TotalSupply(pair)
+	require.NoError(t, err)
+	require.Equal(t, int64(1414213), supply.Int64()) // sqrt(1000000 * 2000000)
+	liquidity, err := h.BalanceOf(pair, h.Auth.From)
+	require.NoError(t, err)
+	require.Equal(t, int64(1414213-1000), liquidity.Int64())
+
+	// pay 1000 token0 in and take out the amount allowed by the constant product formula
+	require.NoError(t, h.Mint(token0, pair, big.NewInt(1000)))
+	amountOut := big.NewInt(1992) // 1000 * 997 * 2000000 / (1000000 * 1000 + 1000 * 997)
+	tooMuch := new(big.Int).Add(amountOut, big.NewInt(1))
+	require.Error(t, h.Transact(pair, uniswapv2pair.Uniswapv2pairABI, "swap", big.NewInt(0), tooMuch, h.Auth.From, []byte{}))
+	require.NoError(t, h.Transact(pair, uniswapv2pair.Uniswapv2pairABI, "swap", big.NewInt(0), amountOut, h.Auth.From, []byte{}))
+	balance, err := h.BalanceOf(token1, h.Auth.From)
+	require.NoError(t, err)
+	require.Equal(t, amountOut.Int64(), balance.Int64())
+	reserves, err = caller.GetReserves(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1001000), reserves.Reserve0.Int64())
+	require.Equal(t, int64(2000000-1992), reserves.Reserve1.Int64())
+
+	// the swap emits a Swap event
+	filterer, err := uniswapv2pair.NewUniswapv2pairFilterer(pair, h.Backend)
+	require.NoError(t, err)
+	swaps, err := filterer.FilterSwap(&bind.FilterOpts{}, nil, nil)
+	require.NoError(t, err)
+	require.True(t, swaps.Next())
+	require.Equal(t, int64(1000), swaps.Event.Amount0In.Int64())
+	require.Equal(t, amountOut.Int64(), swaps.Event.Amount1Out.Int64())
+	require.Equal(t, h.Auth.From, swaps.Event.To)
+}
+
+func TestFactory(t *testing.T) {
+	h, err := New(MainnetTokens...)
+	require.NoError(t, err)
+	defer h.Close()
+	pair, err := h.CreatePair(bclient.DAITokenAddress, bclient.WETHTokenAddress)
+	require.NoError(t, err)
+	require.NotEqual(t, common.Address{}, pair)
+	// the pair may only be created once, in either order
+	_, err = h.CreatePair(bclient.WETHTokenAddress, bclient.DAITokenAddress)
+	require.Error(t, err)
+	_, err = h.CreatePair(bclient.WETHTokenAddress, bclient.WETHTokenAddress)
+	require.Error(t, err)
+
+	factory, err := uniswapv2factory.NewUniswapv2factoryCaller(uniswap.FactoryAddress, h.Backend)
+	require.NoError(t, err)
+	length, err := factory.AllPairsLength(nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), length.Int64())
+	first, err := factory.AllPairs(nil, big.NewInt(0))
+	require.NoError(t, err)
+	require.Equal(t, pair, first)
+	reversed, err := factory.GetPair(nil, bclient.WETHTokenAddress, bclient.DAITokenAddress)
+	require.NoError(t, err)
+	require.Equal(t, pair, reversed)
+	// only the factory may initialize its pairs
+	require.Error(t, h.Transact(pair, uniswapv2pair.Uniswapv2pairABI, "initialize", bclient.WETHTokenAddress, bclient.DAITokenAddress))
+
+	// pairs are deployed with CREATE2 salted by the sorted tokens
+	token0, token1 := bclient.DAITokenAddress, bclient.WETHTokenAddress
+	var salt [32]byte
+	copy(salt[:], crypto.Keccak256(append(token0.Bytes(), token1.Bytes()...)))
+	require.Equal(t, crypto.CreateAddress2(uniswap.FactoryAddress, salt, crypto.Keccak256(common.FromHex(pairBin))), pair)
+
+	filterer, err := uniswapv2factory.NewUniswapv2factoryFilterer(uniswap.FactoryAddress, h.Backend)
+	require.NoError(t, err)
+	created, err := filterer.FilterPairCreated(&bind.FilterOpts{}, nil, nil)
+	require.NoError(t, err)
+	require.True(t, created.Next())
+	require.Equal(t, token0, created.Event.Token0)
+	require.Equal(t, token1, created.Event.Token1)
+	require.Equal(t, pair, created.Event.Pair)
+}
+
+func TestClient(t *testing.T) {
+	h, err := New(MainnetTokens...)
+	require.NoError(t, err)
+	client := h.Client()
+	defer client.Close()
+	_, err = h.SeedPools(Pool{
+		TokenA:  bclient.WETHTokenAddress,
+		TokenB:  bclient.DAITokenAddress,
+		AmountA: utils.ToWei(int64(100), 18),
+		AmountB: utils.ToWei(int64(200000), 18),
+	})
+	require.NoError(t, err)
+
+	block, err := client.CurrentBlock()
+	require.NoError(t, err)
+	require.NotZero(t, block)
+	token, err := client.TokenInfo(bclient.DAITokenAddress)
+	require.NoError(t, err)
+	require.Equal(t, "DAI", token.Symbol)
+	require.Equal(t, 18, token.Decimals)
+	reserves, err := client.Reserves(bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String())
+	require.NoError(t, err)
+	require.Equal(t, utils.ToWei(int64(100), 18), reserves.Reserve0)
+	price, err := client.PairPrice(bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String())
+	require.NoError(t, err)
+	require.InDelta(t, 2000, price, 0.0001)
+
+	// transactions sent through the client are signed for the simulated chain
+	require.NoError(t, h.Mint(bclient.DAITokenAddress, h.Auth.From, big.NewInt(100)))
+	recipient := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	data, err := uniswap.ApproveCalldata(recipient, big.NewInt(100))
+	require.NoError(t, err)
+	tx, err := client.Send(h.Key, bclient.Call{To: bclient.DAITokenAddress, Data: data})
+	require.NoError(t, err)
+	h.Backend.Commit()
+	receipt, err := h.Backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	sender, err := client.TxSender(tx.Hash(), receipt.BlockHash, receipt.TransactionIndex)
+	require.NoError(t, err)
+	require.Equal(t, h.Auth.From, sender)
+	_, err = client.PairPrice(bclient.WETHTokenAddress.String(), bclient.USDCTokenAddress.String())
+	require.True(t, errors.Is(err, uniswap.ErrPairNotFound))
+}
@@ -0,0 +1,128 @@
+// Package health reports the health of the components of a process over http, so orchestrators
+// can restart a stuck process or stop routing to one that can't serve
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Status is the health of a component
+type Status struct {
+	Component string `json:"component"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+	// Updated is when the component last made progress, if it tracks it
+	Updated *time.Time `json:"updated,omitempty"`
+	// Block is the last block the component saw, if it tracks it
+	Block uint64 `json:"block,omitempty"`
+}
+
+// Report is the health of every component checked
+type Report struct {
+	Healthy    bool     `json:"healthy"`
+	Components []Status `json:"components"`
+}
+
+// Check reports the health of one or more components
+type Check func(ctx context.Context) []Status
+
+// Func returns a check of a single component that is healthy unless check returns an error
+func Func(component string, check func(ctx context.Context) error) Check {
+	return func(ctx context.Context) []Status {
+		status := Status{Component: component, Healthy: true}
+		if err := check(ctx); err != nil {
+			status.Healthy, status.Error = false, err.Error()
+		}
+		return []Status{status}
+	}
+}
+
+// Checker runs the registered checks. Liveness checks fail when the process is stuck and should
+// be restarted, readiness checks fail when the process can't do its work, ie a dependency is down
+type Checker struct {
+	timeout time.Duration
+
+	mux   sync.Mutex
+	live  []Check
+	ready []Check
+}
+
+// NewChecker returns a checker giving each request timeout to run its checks
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Liveness registers checks reported by /healthz and /readyz
+func (c *Checker) Liveness(checks ...Check) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.live = append(c.live, checks...)
+}
+
+// Readiness registers checks reported by /readyz
+func (c *Checker) Readiness(checks ...Check) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.ready = append(c.ready, checks...)
+}
+
+// Live runs the liveness checks
+func (c *Checker) Live(ctx context.Context) Report {
+	c.mux.Lock()
+	checks := append([]Check(nil), c.live...)
+	c.mux.Unlock()
+	return run(ctx, checks)
+}
+
+// Ready runs the liveness and readiness checks
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mux.Lock()
+	checks := append(append([]Check(nil), c.live...), c.ready...)
+	c.mux.Unlock()
+	return run(ctx, checks)
+}
+
+// Register serves the liveness report at /healthz and the readiness report at /readyz
+func (c *Checker) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", c.handler(c.Live))
+	mux.HandleFunc("/readyz", c.handler(c.Ready))
+}
+
+func (c *Checker) handler(report func(context.Context) Report) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+		defer cancel()
+		rep := report(ctx)
+		w.Header().Set("Content-Type", "application/json")
+		if !rep.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(rep)
+	}
+}
+
+// run runs the checks concurrently, so one slow dependency doesn't delay the others
+func run(ctx context.Context, checks []Check) Report {
+	results := make([][]Status, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = check(ctx)
+		}(i, check)
+	}
+	wg.Wait()
+	report := Report{Healthy: true, Components: []Status{}}
+	for _, statuses := range results {
+		for _, status := range statuses {
+			report.Healthy = report.Healthy && status.Healthy
+			report.Components = append(report.Components, status)
+		}
+	}
+	return report
+}
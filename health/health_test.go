@@ -0,0 +1,58 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	checker := NewChecker(time.Second)
+	rpcErr := errors.New("rpc down")
+	var rpcFailing bool
+	checker.Liveness(Func("rpc", func(ctx context.Context) error {
+		if rpcFailing {
+			return rpcErr
+		}
+		return nil
+	}))
+	checker.Readiness(Func("db", func(ctx context.Context) error { return errors.New("db down") }))
+	mux := http.NewServeMux()
+	checker.Register(mux)
+
+	type want struct {
+		code       int
+		components int
+	}
+	tests := []struct {
+		name       string
+		path       string
+		rpcFailing bool
+		want       want
+	}{
+		{"live", "/healthz", false, want{http.StatusOK, 1}},
+		{"stuck", "/healthz", true, want{http.StatusServiceUnavailable, 1}},
+		{"not ready", "/readyz", false, want{http.StatusServiceUnavailable, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcFailing = tt.rpcFailing
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, tt.want.code, rec.Code)
+			var report Report
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+			require.Equal(t, tt.want.code == http.StatusOK, report.Healthy)
+			require.Len(t, report.Components, tt.want.components)
+			if tt.rpcFailing {
+				require.Equal(t, rpcErr.Error(), report.Components[0].Error)
+			}
+		})
+	}
+}
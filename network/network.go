@@ -0,0 +1,210 @@
+// Package network provides a registry of the EVM networks unibot can run against, along with
+// the uniswap v2 compatible exchanges, wrapped native token and stablecoins deployed on each
+package network
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownNetwork is returned when looking up a network that isn't registered
+var ErrUnknownNetwork = errors.New("unknown network")
+
+// Network describes an EVM chain and the contracts unibot uses on it
+type Network struct {
+	Name    string
+	ChainID uint64
+	// InfuraHTTPURL and InfuraWSURL are the infura endpoints the api key is appended to,
+	// and are empty if infura doesn't serve the network
+	InfuraHTTPURL string
+	InfuraWSURL   string
+	// RPCURL is a public endpoint used when no RPC endpoint or infura api key is configured
+	RPCURL      string
+	ExplorerURL string
+	// DEXes are the uniswap v2 compatible exchanges on the network, with the first being the default
+	DEXes []uniswap.Venue
+	// WrappedNative is the wrapped version of the network's native currency, such as WETH
+	WrappedNative common.Address
+	NativeSymbol  string
+	// Stablecoins are used to derive USD prices, with the first being used to value amounts
+	Stablecoins []common.Address
+	// ENSRegistry is the ENS registry used to resolve names, zero if ENS isn't deployed on the network
+	ENSRegistry common.Address
+}
+
+// DEX returns the default exchange of the network
+func (n *Network) DEX() uniswap.Venue {
+	return n.DEXes[0]
+}
+
+// LookupDEX returns the exchange on the network with the given name
+func (n *Network) LookupDEX(name string) (uniswap.Venue, bool) {
+	for _, dex := range n.DEXes {
+		if strings.EqualFold(dex.Name, name) {
+			return dex, true
+		}
+	}
+	return uniswap.Venue{}, false
+}
+
+// InfuraURL returns the infura endpoint for the network using the given api key
+func (n *Network) InfuraURL(apiKey string, websockets bool) (string, error) {
+	url := n.InfuraHTTPURL
+	if websockets {
+		url = n.InfuraWSURL
+	}
+	if url == "" {
+		return "", fmt.Errorf("infura does not support %s", n.Name)
+	}
+	return url + apiKey, nil
+}
+
+// ensRegistry is the address of the ENS registry on mainnet and the ethereum testnets
+var ensRegistry = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+var (
+	// Mainnet is the ethereum mainnet
+	Mainnet = &Network{
+		Name:          "mainnet",
+		ChainID:       1,
+		InfuraHTTPURL: "https://mainnet.infura.io/v3/",
+		InfuraWSURL:   "wss://mainnet.infura.io/ws/v3/",
+		ExplorerURL:   "https://etherscan.io",
+		DEXes:         []uniswap.Venue{uniswap.UniswapV2, uniswap.SushiSwap},
+		WrappedNative: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		NativeSymbol:  "ETH",
+		Stablecoins: []common.Address{
+			common.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f"), // DAI
+			common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"), // USDC
+			common.HexToAddress("0xdac17f958d2ee523a2206206994597c13d831ec7"), // USDT
+		},
+		ENSRegistry: ensRegistry,
+	}
+	// Goerli is the goerli ethereum testnet, where uniswap v2 is deployed at its mainnet addresses
+	Goerli = &Network{
+		Name:          "goerli",
+		ChainID:       5,
+		InfuraHTTPURL: "https://goerli.infura.io/v3/",
+		InfuraWSURL:   "wss://goerli.infura.io/ws/v3/",
+		ExplorerURL:   "https://goerli.etherscan.io",
+		DEXes:         []uniswap.Venue{uniswap.UniswapV2},
+		WrappedNative: common.HexToAddress("0xB4FBF271143F4FBf7B91A5ded31805e42b2208d6"),
+		NativeSymbol:  "ETH",
+		Stablecoins: []common.Address{
+			common.HexToAddress("0x11fE4B6AE13d2a6055C8D9cF65c55bac32B5d844"), // DAI
+			common.HexToAddress("0x07865c6E87B9F70255377e024ace6630C1Eaa37F"), // USDC
+		},
+		ENSRegistry: ensRegistry,
+	}
+	// Sepolia is the sepolia ethereum testnet
+	Sepolia = &Network{
+		Name:          "sepolia",
+		ChainID:       11155111,
+		InfuraHTTPURL: "https://sepolia.infura.io/v3/",
+		InfuraWSURL:   "wss://sepolia.infura.io/ws/v3/",
+		ExplorerURL:   "https://sepolia.etherscan.io",
+		DEXes: []uniswap.Venue{{
+			Name:         "uniswap",
+			Factory:      common.HexToAddress("0xF62c03E08ada871A0bEb309762E260a7a6a880E6"),
+			Router:       common.HexToAddress("0xeE567Fe1712Faf6149d80dA1E6934E354124CfE3"),
+			InitCodeHash: uniswap.UniswapV2.InitCodeHash,
+			Fee:          30,
+		}},
+		WrappedNative: common.HexToAddress("0xfFf9976782d46CC05630D1f6eBAb18b2324d6B14"),
+		NativeSymbol:  "ETH",
+		// sepolia has no canonical DAI deployment, so circle's USDC is used on its own
+		Stablecoins: []common.Address{
+			common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238"), // USDC
+		},
+		ENSRegistry: ensRegistry,
+	}
+	// Polygon is the polygon proof of stake chain, using quickswap
+	Polygon = &Network{
+		Name:          "polygon",
+		ChainID:       137,
+		InfuraHTTPURL: "https://polygon-mainnet.infura.io/v3/",
+		InfuraWSURL:   "wss://polygon-mainnet.infura.io/ws/v3/",
+		RPCURL:        "https://polygon-rpc.com",
+		ExplorerURL:   "https://polygonscan.com",
+		DEXes: []uniswap.Venue{{
+			Name:         "quickswap",
+			Factory:      common.HexToAddress("0x5757371414417b8C6CAad45bAeF941aBc7d3Ab32"),
+			Router:       common.HexToAddress("0xa5E0829CaCEd8fFDD4De3c43696c57F7D7A678ff"),
+			InitCodeHash: uniswap.UniswapV2.InitCodeHash,
+			Fee:          30,
+		}},
+		WrappedNative: common.HexToAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"),
+		NativeSymbol:  "MATIC",
+		Stablecoins: []common.Address{
+			common.HexToAddress("0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063"), // DAI
+			common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"), // USDC
+			common.HexToAddress("0xc2132D05D31c914a87C6611C10748AEb04B58e8F"), // USDT
+		},
+	}
+	// BSC is the binance smart chain, using pancakeswap
+	BSC = &Network{
+		Name:        "bsc",
+		ChainID:     56,
+		RPCURL:      "https://bsc-dataseed.binance.org",
+		ExplorerURL: "https://bscscan.com",
+		DEXes: []uniswap.Venue{{
+			Name:         "pancakeswap",
+			Factory:      common.HexToAddress("0xcA143Ce32Fe78f1f7019d7d551a6402fC5350c73"),
+			Router:       common.HexToAddress("0x10ED43C718714eb63d5aA57B78B54704E256024E"),
+			InitCodeHash: common.HexToHash("00fb7f630766e6a796048ea87d01acd3068e8ff67d078148a3fa3f4a84f69bd5"),
+			Fee:          25,
+		}},
+		WrappedNative: common.HexToAddress("0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c"),
+		NativeSymbol:  "BNB",
+		Stablecoins: []common.Address{
+			common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56"), // BUSD
+			common.HexToAddress("0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d"), // USDC
+			common.HexToAddress("0x55d398326f99059fF775485246999027B3197955"), // USDT
+		},
+	}
+
+	// networks are the registered networks keyed by chain id
+	networks = map[uint64]*Network{}
+)
+
+func init() {
+	for _, n := range []*Network{Mainnet, Goerli, Sepolia, Polygon, BSC} {
+		Register(n)
+	}
+}
+
+// Register adds the network to the registry, replacing any network with the same chain id. It isn't safe
+// for concurrent use, so networks should be registered during initialization
+func Register(n *Network) {
+	networks[n.ChainID] = n
+}
+
+// ByChainID returns the network with the given chain id
+func ByChainID(chainID uint64) (*Network, error) {
+	n, ok := networks[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: chain id %d", ErrUnknownNetwork, chainID)
+	}
+	return n, nil
+}
+
+// Lookup returns the network with the given name or chain id, defaulting to Mainnet if empty
+func Lookup(name string) (*Network, error) {
+	if name == "" {
+		return Mainnet, nil
+	}
+	if chainID, err := strconv.ParseUint(name, 10, 64); err == nil {
+		return ByChainID(chainID)
+	}
+	for _, n := range networks {
+		if strings.EqualFold(n.Name, name) {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, name)
+}
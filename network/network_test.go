@@ -0,0 +1,74 @@
+package network
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    *Network
+		wantErr bool
+	}{
+		{"", Mainnet, false},
+		{"mainnet", Mainnet, false},
+		{"Polygon", Polygon, false},
+		{"56", BSC, false},
+		{"11155111", Sepolia, false},
+		{"goerli", Goerli, false},
+		{"ropsten", nil, true},
+		{"1234", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup(tt.name)
+			if tt.wantErr {
+				require.True(t, errors.Is(err, ErrUnknownNetwork))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStablecoins(t *testing.T) {
+	// USD and DAI prices, and so every watcher, fail on networks without stablecoins
+	for _, n := range networks {
+		require.NotEmpty(t, n.Stablecoins, n.Name)
+	}
+}
+
+func TestInfuraURL(t *testing.T) {
+	url, err := Mainnet.InfuraURL("key", false)
+	require.NoError(t, err)
+	require.Equal(t, "https://mainnet.infura.io/v3/key", url)
+	url, err = Polygon.InfuraURL("key", true)
+	require.NoError(t, err)
+	require.Equal(t, "wss://polygon-mainnet.infura.io/ws/v3/key", url)
+	_, err = BSC.InfuraURL("key", false)
+	require.Error(t, err)
+}
+
+func TestDEX(t *testing.T) {
+	dex, ok := Mainnet.LookupDEX("SushiSwap")
+	require.True(t, ok)
+	require.Equal(t, "sushiswap", dex.Name)
+	_, ok = Polygon.LookupDEX("sushiswap")
+	require.False(t, ok)
+	// pairs are found at the addresses derived from each exchange's factory and init code hash
+	require.Equal(
+		t,
+		common.HexToAddress("0x6e7a5FAFcec6BB1e78bAE2A1F0B612012BF14827"),
+		Polygon.DEX().PairAddress(Polygon.WrappedNative, Polygon.Stablecoins[1]),
+	)
+	require.Equal(
+		t,
+		common.HexToAddress("0x58F876857a02D6762E0101bb5C46A8c1ED44Dc16"),
+		BSC.DEX().PairAddress(BSC.WrappedNative, BSC.Stablecoins[0]),
+	)
+}
@@ -0,0 +1,137 @@
+package pricing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Currency is a currency prices can be displayed in
+type Currency string
+
+const (
+	// Native is the quote token of the pair itself
+	Native Currency = "native"
+	// ETH denominates prices in ether, or the native currency of networks other than mainnet
+	ETH Currency = "eth"
+	// DAI denominates prices in DAI, or the first stablecoin of networks other than mainnet
+	DAI Currency = "dai"
+	// USD denominates prices in dollars, as given by the stablecoin anchors
+	USD Currency = "usd"
+)
+
+// DefaultAnchors are the stablecoins used to derive USD prices when none are configured
+var DefaultAnchors = []common.Address{
+	bclient.DAITokenAddress,
+	bclient.USDCTokenAddress,
+	bclient.USDTTokenAddress,
+}
+
+// ParseCurrency parses a currency name, case insensitively
+func ParseCurrency(name string) (Currency, error) {
+	switch currency := Currency(strings.ToLower(name)); currency {
+	case Native, ETH, DAI, USD:
+		return currency, nil
+	default:
+		return "", fmt.Errorf("unsupported currency %s", name)
+	}
+}
+
+// Converter converts prices denominated in a pair's quote token into other currencies.
+// Quote tokens are valued by chaining through the network's wrapped native token, which is
+// WETH on mainnet, into a set of stablecoin anchors
+type Converter struct {
+	bc      *bclient.Client
+	anchors []common.Address
+}
+
+// NewConverter returns a converter using the given stablecoin anchors, or the stablecoins of the
+// client's network if empty, which on mainnet are DefaultAnchors
+func NewConverter(bc *bclient.Client, anchors []common.Address) *Converter {
+	if len(anchors) == 0 {
+		anchors = bc.Network().Stablecoins
+	}
+	return &Converter{bc: bc, anchors: anchors}
+}
+
+// Convert converts a price denominated in quote into the given currency
+func (c *Converter) Convert(price float64, quote common.Address, currency Currency) (float64, error) {
+	var (
+		rate float64
+		err  error
+	)
+	switch currency {
+	case Native:
+		return price, nil
+	case ETH:
+		rate, err = c.QuoteETH(quote)
+	case DAI:
+		stablecoins := c.bc.Network().Stablecoins
+		if len(stablecoins) == 0 {
+			return 0, ErrNoPrice
+		}
+		rate, err = c.QuoteIn(quote, stablecoins[0])
+	case USD:
+		rate, err = c.QuoteUSD(quote)
+	default:
+		return 0, fmt.Errorf("unsupported currency %s", currency)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return price * rate, nil
+}
+
+// QuoteETH returns the value of one whole quote token in ETH, or the network's native currency
+func (c *Converter) QuoteETH(quote common.Address) (float64, error) {
+	wrapped := c.bc.Network().WrappedNative
+	if quote == wrapped {
+		return 1, nil
+	}
+	return c.bc.PairPrice(quote.String(), wrapped.String())
+}
+
+// QuoteIn returns the value of one whole quote token in the given stablecoin, going through the
+// wrapped native token
+func (c *Converter) QuoteIn(quote, stable common.Address) (float64, error) {
+	if quote == stable {
+		return 1, nil
+	}
+	quoteETH, err := c.QuoteETH(quote)
+	if err != nil {
+		return 0, err
+	}
+	ethPrice, err := c.bc.PairPrice(c.bc.Network().WrappedNative.String(), stable.String())
+	if err != nil {
+		return 0, err
+	}
+	return quoteETH * ethPrice, nil
+}
+
+// QuoteUSD returns the value of one whole quote token in USD, being the
+// median of its value in each of the anchors. Anchors are worth exactly one dollar
+func (c *Converter) QuoteUSD(quote common.Address) (float64, error) {
+	for _, anchor := range c.anchors {
+		if quote == anchor {
+			return 1, nil
+		}
+	}
+	quoteETH, err := c.QuoteETH(quote)
+	if err != nil {
+		return 0, err
+	}
+	prices := make([]float64, 0, len(c.anchors))
+	for _, anchor := range c.anchors {
+		ethPrice, err := c.bc.PairPrice(c.bc.Network().WrappedNative.String(), anchor.String())
+		if err != nil {
+			continue
+		}
+		prices = append(prices, quoteETH*ethPrice)
+	}
+	if len(prices) == 0 {
+		return 0, ErrNoPrice
+	}
+	return Median(prices), nil
+}
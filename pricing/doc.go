@@ -0,0 +1,2 @@
+// Package pricing provides pluggable price sources for token pairs
+package pricing
@@ -0,0 +1,101 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNoPrice is returned when none of the aggregated sources returned a price
+	ErrNoPrice = errors.New("no price available")
+	// ErrPriceDeviation is returned when too few sources agree on a price
+	ErrPriceDeviation = errors.New("price sources deviate")
+)
+
+// MedianSource aggregates several sources, returning the median of their prices
+type MedianSource struct {
+	sources      []PriceSource
+	maxDeviation float64
+}
+
+// NewMedianSource returns a source returning the median price of the given sources.
+// If maxDeviation is non-zero, prices deviating from the median by more than maxDeviation
+// (as a fraction, ie 0.05 is 5%) are discarded, and if that leaves no more than half of
+// the sources the price is rejected
+func NewMedianSource(maxDeviation float64, sources ...PriceSource) *MedianSource {
+	return &MedianSource{sources: sources, maxDeviation: maxDeviation}
+}
+
+// Name returns the name of the source
+func (m *MedianSource) Name() string {
+	names := make([]string, 0, len(m.sources))
+	for _, source := range m.sources {
+		names = append(names, source.Name())
+	}
+	return "median(" + strings.Join(names, ",") + ")"
+}
+
+// Prices returns the price of every source that didn't fail, keyed by source name
+func (m *MedianSource) Prices() map[string]float64 {
+	prices := make(map[string]float64, len(m.sources))
+	for _, source := range m.sources {
+		price, err := source.Price()
+		if err != nil {
+			log.Printf("failed to get price from source %s: %s\n", source.Name(), err)
+			continue
+		}
+		prices[source.Name()] = price
+	}
+	return prices
+}
+
+// Price returns the median price of all sources
+func (m *MedianSource) Price() (float64, error) {
+	prices := make([]float64, 0, len(m.sources))
+	for _, price := range m.Prices() {
+		prices = append(prices, price)
+	}
+	if len(prices) == 0 {
+		return 0, ErrNoPrice
+	}
+	median := Median(prices)
+	if m.maxDeviation <= 0 {
+		return median, nil
+	}
+	agreeing := make([]float64, 0, len(prices))
+	for _, price := range prices {
+		if Deviation(price, median) <= m.maxDeviation {
+			agreeing = append(agreeing, price)
+		}
+	}
+	if len(agreeing)*2 <= len(m.sources) {
+		return 0, fmt.Errorf("%w: only %d of %d sources within %.2f%% of median", ErrPriceDeviation, len(agreeing), len(m.sources), m.maxDeviation*100)
+	}
+	return Median(agreeing), nil
+}
+
+// Median returns the median of the given prices
+func Median(prices []float64) float64 {
+	if len(prices) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), prices...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// Deviation returns how far price is from reference as a fraction of reference
+func Deviation(price, reference float64) float64 {
+	if reference == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(price-reference) / math.Abs(reference)
+}
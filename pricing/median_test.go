@@ -0,0 +1,69 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type staticSource struct {
+	name  string
+	price float64
+	err   error
+}
+
+func (s *staticSource) Name() string            { return s.name }
+func (s *staticSource) Price() (float64, error) { return s.price, s.err }
+
+func TestMedianSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxDeviation float64
+		sources      []PriceSource
+		want         float64
+		wantErr      error
+	}{
+		{
+			"odd", 0, []PriceSource{
+				&staticSource{name: "a", price: 1}, &staticSource{name: "b", price: 3}, &staticSource{name: "c", price: 2},
+			}, 2, nil,
+		},
+		{
+			"even", 0, []PriceSource{
+				&staticSource{name: "a", price: 1}, &staticSource{name: "b", price: 2},
+			}, 1.5, nil,
+		},
+		{
+			"failing-source-ignored", 0, []PriceSource{
+				&staticSource{name: "a", price: 1}, &staticSource{name: "b", err: errors.New("fail")},
+			}, 1, nil,
+		},
+		{
+			"all-failing", 0, []PriceSource{
+				&staticSource{name: "a", err: errors.New("fail")},
+			}, 0, ErrNoPrice,
+		},
+		{
+			"outlier-discarded", 0.05, []PriceSource{
+				&staticSource{name: "a", price: 100}, &staticSource{name: "b", price: 101}, &staticSource{name: "c", price: 150},
+			}, 100.5, nil,
+		},
+		{
+			"no-majority", 0.05, []PriceSource{
+				&staticSource{name: "a", price: 100}, &staticSource{name: "b", price: 150},
+			}, 0, ErrPriceDeviation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := NewMedianSource(tt.maxDeviation, tt.sources...).Price()
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, price)
+		})
+	}
+}
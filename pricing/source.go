@@ -0,0 +1,90 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrStalePrice is returned when an oracle hasn't been updated within the allowed age
+var ErrStalePrice = errors.New("stale price")
+
+// PriceSource provides the price of a single pair from a venue or oracle
+type PriceSource interface {
+	// Name identifies the source in logs and messages
+	Name() string
+	// Price returns the current price of the pair
+	Price() (float64, error)
+}
+
+// UniswapSource prices a pair using the reserves of its uniswap v2 pool
+type UniswapSource struct {
+	bc     *bclient.Client
+	token0 string
+	token1 string
+}
+
+// NewUniswapSource returns a price source for the token0/token1 uniswap pair
+func NewUniswapSource(bc *bclient.Client, token0, token1 string) *UniswapSource {
+	return &UniswapSource{bc: bc, token0: token0, token1: token1}
+}
+
+// Name returns the name of the source
+func (u *UniswapSource) Name() string { return "uniswap" }
+
+// Price returns the mid price of one whole token0 in token1, adjusted for the decimals of both tokens
+func (u *UniswapSource) Price() (float64, error) {
+	return u.bc.SpotPrice(u.token0, u.token1)
+}
+
+// UniswapV3Source prices a pair using a uniswap v3 pool
+type UniswapV3Source struct {
+	bc     *bclient.Client
+	token0 string
+	token1 string
+	fee    uint32
+}
+
+// NewUniswapV3Source returns a price source for the token0/token1 uniswap v3 pool with the given fee tier
+func NewUniswapV3Source(bc *bclient.Client, token0, token1 string, fee uint32) *UniswapV3Source {
+	return &UniswapV3Source{bc: bc, token0: token0, token1: token1, fee: fee}
+}
+
+// Name returns the name of the source
+func (u *UniswapV3Source) Name() string { return "uniswap-v3" }
+
+// Price returns the price of the pair
+func (u *UniswapV3Source) Price() (float64, error) {
+	return u.bc.V3PairPrice(u.token0, u.token1, u.fee)
+}
+
+// ChainlinkSource prices a pair using a chainlink aggregator
+type ChainlinkSource struct {
+	bc     *bclient.Client
+	feed   common.Address
+	maxAge time.Duration
+}
+
+// NewChainlinkSource returns a price source for the given aggregator. If maxAge is
+// non-zero, answers that haven't been updated within maxAge are rejected
+func NewChainlinkSource(bc *bclient.Client, feed common.Address, maxAge time.Duration) *ChainlinkSource {
+	return &ChainlinkSource{bc: bc, feed: feed, maxAge: maxAge}
+}
+
+// Name returns the name of the source
+func (c *ChainlinkSource) Name() string { return "chainlink" }
+
+// Price returns the latest answer of the aggregator
+func (c *ChainlinkSource) Price() (float64, error) {
+	price, updatedAt, err := c.bc.ChainlinkPrice(c.feed)
+	if err != nil {
+		return 0, err
+	}
+	if c.maxAge > 0 && time.Since(updatedAt) > c.maxAge {
+		return 0, fmt.Errorf("%w: %s last updated at %s", ErrStalePrice, c.feed, updatedAt)
+	}
+	return price, nil
+}
@@ -0,0 +1,130 @@
+package uniswap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Arbitrage is a round trip that swaps TokenIn for Token on the Buy venue and
+// then swaps the received Token back to TokenIn on the Sell venue
+type Arbitrage struct {
+	TokenIn   common.Address
+	Token     common.Address
+	Buy       Venue
+	Sell      Venue
+	AmountIn  *big.Int
+	AmountOut *big.Int
+	// Profit is AmountOut - AmountIn denominated in TokenIn, excluding gas costs
+	Profit *big.Int
+}
+
+// OptimalArbitrageAmount returns the amount of the input token that maximizes the profit of swapping
+// it through pool A (reserveAIn, reserveAOut) and swapping the output back through pool B
+// (reserveBIn, reserveBOut). Zero is returned if no amount is profitable.
+//
+// Two constant product pools chained together behave like a single virtual pool with reserves
+// Ea = aIn * bIn / (bIn + gB * aOut) and Eb = gB * aOut * bOut / (bIn + gB * aOut), where g is one
+// minus the fee. Maximizing gA * Eb * x / (Ea + gA * x) - x gives x = (sqrt(gA * Ea * Eb) - Ea) / gA
+func OptimalArbitrageAmount(reserveAIn, reserveAOut, reserveBIn, reserveBOut *big.Int, feeA, feeB int64) *big.Int {
+	zero := big.NewInt(0)
+	if reserveAIn.Cmp(zero) <= 0 || reserveAOut.Cmp(zero) <= 0 ||
+		reserveBIn.Cmp(zero) <= 0 || reserveBOut.Cmp(zero) <= 0 {
+		return new(big.Int)
+	}
+	denom := big.NewInt(10000)
+	gA := big.NewInt(10000 - feeA)
+	gB := big.NewInt(10000 - feeB)
+	// the fees are scaled by 10000 so the virtual pool denominator is scaled to match
+	virtual := new(big.Int).Mul(reserveBIn, denom)
+	virtual.Add(virtual, new(big.Int).Mul(gB, reserveAOut))
+	ea := new(big.Int).Mul(reserveAIn, reserveBIn)
+	ea.Mul(ea, denom)
+	ea.Div(ea, virtual)
+	eb := new(big.Int).Mul(gB, reserveAOut)
+	eb.Mul(eb, reserveBOut)
+	eb.Div(eb, virtual)
+	// sqrt(gA * Ea * Eb) with gA scaled by 10000
+	root := new(big.Int).Mul(gA, ea)
+	root.Mul(root, eb)
+	root.Div(root, denom)
+	root.Sqrt(root)
+	if root.Cmp(ea) <= 0 {
+		return new(big.Int)
+	}
+	amount := root.Sub(root, ea)
+	amount.Mul(amount, denom)
+	return amount.Div(amount, gA)
+}
+
+// ArbitrageAmountOut returns the amount of the input token received after swapping amountIn through
+// pool A and the output back through pool B
+func ArbitrageAmountOut(amountIn, reserveAIn, reserveAOut, reserveBIn, reserveBOut *big.Int, feeA, feeB int64) *big.Int {
+	return GetAmountOutWithFee(
+		GetAmountOutWithFee(amountIn, reserveAIn, reserveAOut, feeA),
+		reserveBIn, reserveBOut, feeB,
+	)
+}
+
+// FindArbitrage compares the tokenIn/token pair across the given venues and returns the most profitable
+// round trip starting and ending in tokenIn. Venues without the pair are skipped, and if no round trip
+// is profitable before gas costs nil is returned
+func (c *Client) FindArbitrage(tokenIn, token common.Address, venues []Venue) (*Arbitrage, error) {
+	type quote struct {
+		venue    Venue
+		reserves *Reserve
+	}
+	quotes := make([]quote, 0, len(venues))
+	for _, venue := range venues {
+		reserves, err := c.GetVenueReservesAt(venue, tokenIn, token, nil)
+		if err != nil {
+			if errors.Is(err, ErrPairNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		quotes = append(quotes, quote{venue, reserves})
+	}
+	if len(quotes) < 2 {
+		return nil, fmt.Errorf("%w: %s/%s is deployed on less than two venues", ErrPairNotFound, tokenIn, token)
+	}
+	var best *Arbitrage
+	for _, buy := range quotes {
+		for _, sell := range quotes {
+			if buy.venue.Factory == sell.venue.Factory {
+				continue
+			}
+			// reserves are ordered tokenIn, token so the sell pool is swapped in reverse
+			amountIn := OptimalArbitrageAmount(
+				buy.reserves.Reserve0, buy.reserves.Reserve1,
+				sell.reserves.Reserve1, sell.reserves.Reserve0,
+				buy.venue.Fee, sell.venue.Fee,
+			)
+			if amountIn.Sign() <= 0 {
+				continue
+			}
+			amountOut := ArbitrageAmountOut(
+				amountIn,
+				buy.reserves.Reserve0, buy.reserves.Reserve1,
+				sell.reserves.Reserve1, sell.reserves.Reserve0,
+				buy.venue.Fee, sell.venue.Fee,
+			)
+			profit := new(big.Int).Sub(amountOut, amountIn)
+			if profit.Sign() <= 0 || (best != nil && profit.Cmp(best.Profit) <= 0) {
+				continue
+			}
+			best = &Arbitrage{
+				TokenIn:   tokenIn,
+				Token:     token,
+				Buy:       buy.venue,
+				Sell:      sell.venue,
+				AmountIn:  amountIn,
+				AmountOut: amountOut,
+				Profit:    profit,
+			}
+		}
+	}
+	return best, nil
+}
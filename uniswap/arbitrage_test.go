@@ -0,0 +1,66 @@
+package uniswap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestVenuePairAddress(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	if got, want := UniswapV2.PairAddress(weth, usdc), GeneratePairAddress(usdc, weth); got != want {
+		t.Errorf("UniswapV2.PairAddress() = %v, want %v", got, want)
+	}
+	if UniswapV2.PairAddress(weth, usdc) == SushiSwap.PairAddress(weth, usdc) {
+		t.Error("venues with different factories generated the same pair address")
+	}
+	if venue, ok := LookupVenue("SushiSwap"); !ok || venue.Factory != SushiSwap.Factory {
+		t.Errorf("LookupVenue() = %v, %v", venue, ok)
+	}
+}
+
+func TestOptimalArbitrageAmount(t *testing.T) {
+	ether := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	eth := func(v int64) *big.Int { return new(big.Int).Mul(big.NewInt(v), ether) }
+	tests := []struct {
+		name                 string
+		aIn, aOut, bIn, bOut *big.Int
+		wantProfit           bool
+	}{
+		// 1000 ETH at 2200 DAI on A, 1000 ETH at 2000 DAI on B
+		{"profitable", eth(1000), eth(2200000), eth(2000000), eth(1000), true},
+		{"reverse", eth(1000), eth(2000000), eth(2200000), eth(1000), false},
+		{"equal-prices", eth(1000), eth(2000000), eth(2000000), eth(1000), false},
+		// a 0.4% divergence is less than the 0.6% paid in fees
+		{"within-fees", eth(1000), eth(2008000), eth(2000000), eth(1000), false},
+		{"empty-pool", eth(1000), eth(2200000), new(big.Int), eth(1000), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amountIn := OptimalArbitrageAmount(tt.aIn, tt.aOut, tt.bIn, tt.bOut, 30, 30)
+			if !tt.wantProfit {
+				if amountIn.Sign() != 0 {
+					t.Fatalf("OptimalArbitrageAmount() = %v, want 0", amountIn)
+				}
+				return
+			}
+			profit := func(amount *big.Int) *big.Int {
+				out := ArbitrageAmountOut(amount, tt.aIn, tt.aOut, tt.bIn, tt.bOut, 30, 30)
+				return out.Sub(out, amount)
+			}
+			best := profit(amountIn)
+			if best.Sign() <= 0 {
+				t.Fatalf("profit = %v, want > 0", best)
+			}
+			// moving 1% either side of the optimal amount must not be more profitable
+			delta := new(big.Int).Div(amountIn, big.NewInt(100))
+			for _, amount := range []*big.Int{new(big.Int).Sub(amountIn, delta), new(big.Int).Add(amountIn, delta)} {
+				if got := profit(amount); got.Cmp(best) > 0 {
+					t.Errorf("profit(%v) = %v exceeds optimal profit %v", amount, got, best)
+				}
+			}
+		})
+	}
+}
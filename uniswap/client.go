@@ -19,30 +19,135 @@ package uniswap
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
+	"github.com/bonedaddy/unibot/backend"
+	uniswapv2factory "github.com/bonedaddy/unibot/bindings/uniswapv2/factory"
 	uniswapv2pair "github.com/bonedaddy/unibot/bindings/uniswapv2/pair"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+// ErrPairNotFound is returned when no pair has been deployed for the given tokens
+var ErrPairNotFound = errors.New("no such pair")
+
 // Client allows to do operations on uniswap smart contracts.
 type Client struct {
-	bc *ethclient.Client
+	bc backend.Backend
+	// venue is the exchange used unless another is given
+	venue Venue
+
+	pmux  sync.RWMutex
+	pairs map[venuePair]common.Address
+}
+
+// venuePair identifies a pair deployed by a particular factory
+type venuePair struct {
+	Factory common.Address
+	Pair
+}
+
+// NewClient returns a new instance of uniswap client. The backend is usually an
+// ethclient, but may be a simulated or replay backend when testing.
+func NewClient(bc backend.Backend) *Client {
+	return NewVenueClient(bc, UniswapV2)
 }
 
-// NewClient returns a new instance of uniswap client.
-func NewClient(bc *ethclient.Client) *Client {
+// NewVenueClient returns a uniswap client using the given venue in place of uniswap, which
+// allows the client to be used with uniswap forks deployed on other networks
+func NewVenueClient(bc backend.Backend, venue Venue) *Client {
 	return &Client{
-		bc: bc,
+		bc:    bc,
+		venue: venue,
+		pairs: make(map[venuePair]common.Address),
 	}
 }
 
+// Venue returns the venue used by the client
+func (c *Client) Venue() Venue { return c.venue }
+
+// PairAddress returns the address of the pair on the client's venue for the given tokens. Unlike GeneratePairAddress
+// this ensures the pair has actually been deployed, returning ErrPairNotFound if it has not,
+// and that the pair sorts its tokens in the same order we do. Resolved pairs are cached.
+func (c *Client) PairAddress(token0, token1 common.Address) (common.Address, error) {
+	return c.VenuePairAddress(c.venue, token0, token1)
+}
+
+// VenuePairAddress is like PairAddress but resolves the pair deployed on the given venue
+func (c *Client) VenuePairAddress(venue Venue, token0, token1 common.Address) (common.Address, error) {
+	stoken0, stoken1 := sortAddressess(token0, token1)
+	key := venuePair{Factory: venue.Factory, Pair: Pair{Token0: stoken0, Token1: stoken1}}
+	c.pmux.RLock()
+	addr, ok := c.pairs[key]
+	c.pmux.RUnlock()
+	if ok {
+		return addr, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	addr = venue.PairAddress(token0, token1)
+	code, err := c.bc.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(code) == 0 {
+		// nothing is deployed at the computed address so ask the factory before giving up
+		factory, err := uniswapv2factory.NewUniswapv2factoryCaller(venue.Factory, c.bc)
+		if err != nil {
+			return common.Address{}, err
+		}
+		addr, err = factory.GetPair(&bind.CallOpts{Context: ctx}, stoken0, stoken1)
+		if err != nil {
+			return common.Address{}, err
+		}
+		if addr == (common.Address{}) {
+			return common.Address{}, fmt.Errorf("%w: %s/%s", ErrPairNotFound, token0, token1)
+		}
+	}
+	caller, err := uniswapv2pair.NewUniswapv2pairCaller(addr, c.bc)
+	if err != nil {
+		return common.Address{}, err
+	}
+	ptoken0, err := caller.Token0(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, err
+	}
+	ptoken1, err := caller.Token1(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, err
+	}
+	// reserves and swap amounts are flipped based on our sort order, so it must match the pair's
+	if ptoken0 != stoken0 || ptoken1 != stoken1 {
+		return common.Address{}, fmt.Errorf(
+			"pair %s has tokens %s/%s but expected %s/%s",
+			addr, ptoken0, ptoken1, stoken0, stoken1,
+		)
+	}
+	c.pmux.Lock()
+	c.pairs[key] = addr
+	c.pmux.Unlock()
+	return addr, nil
+}
+
 // GetReserves retursn the available reserves in a pair
 func (c *Client) GetReserves(token0, token1 common.Address) (*Reserve, error) {
-	addr := GeneratePairAddress(token0, token1)
+	return c.GetReservesAt(token0, token1, nil)
+}
+
+// GetReservesAt returns the reserves in a pair as of the given block, or the latest block if nil
+func (c *Client) GetReservesAt(token0, token1 common.Address, block *big.Int) (*Reserve, error) {
+	return c.GetVenueReservesAt(c.venue, token0, token1, block)
+}
+
+// GetVenueReservesAt is like GetReservesAt but returns the reserves of the pair deployed on the given venue
+func (c *Client) GetVenueReservesAt(venue Venue, token0, token1 common.Address, block *big.Int) (*Reserve, error) {
+	addr, err := c.VenuePairAddress(venue, token0, token1)
+	if err != nil {
+		return nil, err
+	}
 	caller, err := uniswapv2pair.NewUniswapv2pairCaller(addr, c.bc)
 	if err != nil {
 		return nil, err
@@ -50,7 +155,8 @@ func (c *Client) GetReserves(token0, token1 common.Address) (*Reserve, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	reserves, err := caller.GetReserves(&bind.CallOpts{
-		Context: ctx,
+		Context:     ctx,
+		BlockNumber: block,
 	})
 	if err != nil {
 		return nil, err
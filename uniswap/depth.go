@@ -0,0 +1,99 @@
+package uniswap
+
+import (
+	"math"
+	"math/big"
+)
+
+// DefaultDepthMoves are the price moves, as fractions, that liquidity depth is reported for
+var DefaultDepthMoves = []float64{0.01, 0.02, 0.05, 0.10}
+
+// DepthLevel is the trade needed to move the price of token0, denominated in token1, by Move.
+// Positive moves buy token0 with token1, negative moves sell token0 for token1
+type DepthLevel struct {
+	Move float64
+	// AmountIn is the amount of token1 paid when the move is positive, or token0 when negative
+	AmountIn *big.Int
+	// AmountOut is the amount of token0 received when the move is positive, or token1 when negative
+	AmountOut *big.Int
+}
+
+// Depth returns the amounts that need to be traded against the given reserves to move the price
+// of token0 up and down by each of the moves, accounting for the 0.3% liquidity provider fee.
+// Levels are returned in the order of the moves, with the upward move of each before the downward one
+func Depth(reserve0, reserve1 *big.Int, moves []float64) []DepthLevel {
+	levels := make([]DepthLevel, 0, len(moves)*2)
+	if reserve0.Sign() <= 0 || reserve1.Sign() <= 0 {
+		return levels
+	}
+	for _, move := range moves {
+		move = math.Abs(move)
+		// buying token0 raises the price of token0 by 1 + move
+		amountIn := depthAmountIn(reserve1, 1+move)
+		levels = append(levels, DepthLevel{
+			Move:      move,
+			AmountIn:  amountIn,
+			AmountOut: GetAmountOut(amountIn, reserve1, reserve0),
+		})
+		if move >= 1 {
+			// the price can't fall by 100% or more
+			continue
+		}
+		// selling token0 raises the price of token1 by 1 / (1 - move)
+		amountIn = depthAmountIn(reserve0, 1/(1-move))
+		levels = append(levels, DepthLevel{
+			Move:      -move,
+			AmountIn:  amountIn,
+			AmountOut: GetAmountOut(amountIn, reserve0, reserve1),
+		})
+	}
+	return levels
+}
+
+// depthAmountIn returns the amount that needs to be paid into a pool with reserveIn to raise the
+// price of the token paid out by a factor of m.
+//
+// With g = 0.997 the swap pays out g * in * reserveOut / (reserveIn + g * in), and as the fee is kept
+// by the pool the new price is (reserveIn + in) * (reserveIn + g * in) / (reserveIn * reserveOut).
+// Solving for a price of m * reserveIn / reserveOut gives the quadratic
+// g * in^2 + (1 + g) * reserveIn * in + (1 - m) * reserveIn^2 = 0
+func depthAmountIn(reserveIn *big.Int, m float64) *big.Int {
+	const g = 0.997
+	factor := (math.Sqrt((1+g)*(1+g)+4*g*(m-1)) - (1 + g)) / (2 * g)
+	amount, _ := new(big.Float).Mul(new(big.Float).SetInt(reserveIn), big.NewFloat(factor)).Int(nil)
+	return amount
+}
+
+// OrderBookLevel is the amount of token0 available between the previous level's price and Price
+type OrderBookLevel struct {
+	// Price is the price of token0 denominated in token1, unadjusted for decimals
+	Price float64
+	// Amount is the amount of token0 the pool sells (asks) or buys (bids) within the level
+	Amount *big.Int
+}
+
+// OrderBook builds a synthetic order book ladder from the reserves, with levels spaced step apart as
+// a fraction of the current price. Fees are excluded so the ladder reflects the pool's liquidity alone.
+// Asks are ordered from the lowest price up, and bids from the highest price down
+func OrderBook(reserve0, reserve1 *big.Int, step float64, levels int) (bids, asks []OrderBookLevel) {
+	if reserve0.Sign() <= 0 || reserve1.Sign() <= 0 || step <= 0 {
+		return nil, nil
+	}
+	r0, _ := new(big.Float).SetInt(reserve0).Float64()
+	r1, _ := new(big.Float).SetInt(reserve1).Float64()
+	price := r1 / r0
+	// the reserve of token0 once the price has moved by a factor of m is reserve0 / sqrt(m)
+	reserveAt := func(m float64) float64 { return r0 / math.Sqrt(m) }
+	for i := 1; i <= levels; i++ {
+		upper, lower := 1+float64(i)*step, 1+float64(i-1)*step
+		amount, _ := big.NewFloat(reserveAt(lower) - reserveAt(upper)).Int(nil)
+		asks = append(asks, OrderBookLevel{Price: price * upper, Amount: amount})
+		upper, lower = 1-float64(i-1)*step, 1-float64(i)*step
+		if lower <= 0 {
+			continue
+		}
+		amount, _ = big.NewFloat(reserveAt(lower) - reserveAt(upper)).Int(nil)
+		bids = append(bids, OrderBookLevel{Price: price * lower, Amount: amount})
+	}
+	return bids, asks
+}
@@ -0,0 +1,64 @@
+package uniswap
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestDepth(t *testing.T) {
+	ether := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	reserve0 := new(big.Int).Mul(big.NewInt(1000), ether)
+	reserve1 := new(big.Int).Mul(big.NewInt(2000000), ether)
+	levels := Depth(reserve0, reserve1, DefaultDepthMoves)
+	if len(levels) != len(DefaultDepthMoves)*2 {
+		t.Fatalf("len(Depth()) = %d, want %d", len(levels), len(DefaultDepthMoves)*2)
+	}
+	price := func(r0, r1 *big.Int) float64 {
+		p, _ := new(big.Rat).SetFrac(r1, r0).Float64()
+		return p
+	}
+	startPrice := price(reserve0, reserve1)
+	for _, level := range levels {
+		// apply the trade to the reserves, the whole input including the fee is kept by the pool
+		var r0, r1 *big.Int
+		if level.Move > 0 {
+			r0 = new(big.Int).Sub(reserve0, level.AmountOut)
+			r1 = new(big.Int).Add(reserve1, level.AmountIn)
+		} else {
+			r0 = new(big.Int).Add(reserve0, level.AmountIn)
+			r1 = new(big.Int).Sub(reserve1, level.AmountOut)
+		}
+		got := price(r0, r1)/startPrice - 1
+		if math.Abs(got-level.Move) > 1e-9 {
+			t.Errorf("move %v: price moved by %v", level.Move, got)
+		}
+	}
+	if got := Depth(new(big.Int), reserve1, DefaultDepthMoves); len(got) != 0 {
+		t.Errorf("Depth() with empty reserves = %v, want no levels", got)
+	}
+}
+
+func TestOrderBook(t *testing.T) {
+	reserve0 := big.NewInt(1000000000)
+	reserve1 := big.NewInt(2000000000)
+	bids, asks := OrderBook(reserve0, reserve1, 0.01, 5)
+	if len(bids) != 5 || len(asks) != 5 {
+		t.Fatalf("OrderBook() returned %d bids and %d asks, want 5 each", len(bids), len(asks))
+	}
+	for i := range asks {
+		if asks[i].Amount.Sign() <= 0 || bids[i].Amount.Sign() <= 0 {
+			t.Errorf("level %d has no liquidity", i)
+		}
+		if i > 0 && (asks[i].Price <= asks[i-1].Price || bids[i].Price >= bids[i-1].Price) {
+			t.Errorf("level %d is out of order", i)
+		}
+	}
+	// selling the first ask level moves the price to the level's price
+	r0 := new(big.Int).Sub(reserve0, asks[0].Amount)
+	r1 := new(big.Int).Div(new(big.Int).Mul(reserve0, reserve1), r0)
+	got, _ := new(big.Rat).SetFrac(r1, r0).Float64()
+	if math.Abs(got-asks[0].Price)/asks[0].Price > 1e-6 {
+		t.Errorf("price after first ask = %v, want %v", got, asks[0].Price)
+	}
+}
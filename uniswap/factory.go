@@ -0,0 +1,123 @@
+package uniswap
+
+import (
+	"context"
+	"sort"
+
+	uniswapv2factory "github.com/bonedaddy/unibot/bindings/uniswapv2/factory"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// PairCreated is a pair creation emitted by the factory
+type PairCreated struct {
+	Pair        common.Address
+	Token0      common.Address
+	Token1      common.Address
+	TxHash      common.Hash
+	BlockNumber uint64
+	BlockHash   common.Hash
+	Removed     bool
+}
+
+// logPageSize is the most blocks whose logs are requested at once, keeping each eth_getLogs
+// request within the block range and result limits of providers
+const logPageSize = 2000
+
+// FilterPairCreated returns the pairs created between the start and end block that have any of the
+// tokens as token0 or token1, or every pair if no tokens are given, ordered by block. The range is
+// requested in pages of logPageSize blocks
+func (c *Client) FilterPairCreated(ctx context.Context, start, end uint64, tokens []common.Address) ([]*PairCreated, error) {
+	filterer, err := uniswapv2factory.NewUniswapv2factoryFilterer(c.venue.Factory, c.bc)
+	if err != nil {
+		return nil, err
+	}
+	// the tokens are indexed, so pairs are filtered by the node with one request per token position
+	filters := [][2][]common.Address{{tokens, nil}, {nil, tokens}}
+	if len(tokens) == 0 {
+		filters = filters[:1]
+	}
+	var pairs []*PairCreated
+	seen := make(map[common.Address]bool)
+	for _, page := range logPages(start, end, logPageSize) {
+		for _, filter := range filters {
+			iter, err := filterer.FilterPairCreated(&bind.FilterOpts{Start: page[0], End: &page[1], Context: ctx}, filter[0], filter[1])
+			if err != nil {
+				return nil, err
+			}
+			for iter.Next() {
+				if !seen[iter.Event.Pair] {
+					seen[iter.Event.Pair] = true
+					pairs = append(pairs, newPairCreated(iter.Event))
+				}
+			}
+			err = iter.Error()
+			iter.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].BlockNumber < pairs[j].BlockNumber })
+	return pairs, nil
+}
+
+// logPages splits the inclusive block range into ranges of at most size blocks
+func logPages(start, end, size uint64) [][2]uint64 {
+	var pages [][2]uint64
+	for from := start; from <= end; from += size {
+		to := from + size - 1
+		if to > end || to < from {
+			to = end
+		}
+		pages = append(pages, [2]uint64{from, to})
+		if to == end {
+			break
+		}
+	}
+	return pages
+}
+
+// WatchPairCreated subscribes to pairs created by the factory, sending them to sink until
+// the returned subscription is unsubscribed or fails. Subscriptions require a websocket connection
+func (c *Client) WatchPairCreated(ctx context.Context, sink chan<- *PairCreated) (event.Subscription, error) {
+	filterer, err := uniswapv2factory.NewUniswapv2factoryFilterer(c.venue.Factory, c.bc)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan *uniswapv2factory.Uniswapv2factoryPairCreated)
+	sub, err := filterer.WatchPairCreated(&bind.WatchOpts{Context: ctx}, events, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-events:
+				select {
+				case sink <- newPairCreated(ev):
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func newPairCreated(ev *uniswapv2factory.Uniswapv2factoryPairCreated) *PairCreated {
+	return &PairCreated{
+		Pair:        ev.Pair,
+		Token0:      ev.Token0,
+		Token1:      ev.Token1,
+		TxHash:      ev.Raw.TxHash,
+		BlockNumber: ev.Raw.BlockNumber,
+		BlockHash:   ev.Raw.BlockHash,
+		Removed:     ev.Raw.Removed,
+	}
+}
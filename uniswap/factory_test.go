@@ -0,0 +1,47 @@
+package uniswap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterPairCreated(t *testing.T) {
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	client := h.Client()
+	defer client.Close()
+	var pairs []common.Address
+	for _, tokens := range [][2]common.Address{
+		{bclient.WETHTokenAddress, bclient.DAITokenAddress},
+		{bclient.NDXTokenAddress, bclient.USDCTokenAddress},
+		{bclient.USDCTokenAddress, bclient.WETHTokenAddress},
+	} {
+		pair, err := h.CreatePair(tokens[0], tokens[1])
+		require.NoError(t, err)
+		pairs = append(pairs, pair)
+	}
+	head, err := client.CurrentBlock()
+	require.NoError(t, err)
+	uc := client.Uniswap()
+
+	created, err := uc.FilterPairCreated(context.Background(), 0, head, nil)
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+	// USDC is token1 of the NDX/USDC pair and token0 of the USDC/WETH pair
+	created, err = uc.FilterPairCreated(context.Background(), 0, head, []common.Address{bclient.USDCTokenAddress})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	require.Equal(t, pairs[1], created[0].Pair)
+	require.Equal(t, bclient.USDCTokenAddress, created[0].Token1)
+	require.Equal(t, pairs[2], created[1].Pair)
+	require.Equal(t, bclient.USDCTokenAddress, created[1].Token0)
+	require.Less(t, created[0].BlockNumber, created[1].BlockNumber)
+	created, err = uc.FilterPairCreated(context.Background(), head, head, []common.Address{bclient.NDXTokenAddress})
+	require.NoError(t, err)
+	require.Empty(t, created)
+}
@@ -0,0 +1,82 @@
+package uniswap
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	uniswapv2callee "github.com/bonedaddy/unibot/bindings/uniswapv2/callee"
+	uniswapv2pair "github.com/bonedaddy/unibot/bindings/uniswapv2/pair"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	pairABI, _   = abi.JSON(strings.NewReader(uniswapv2pair.Uniswapv2pairABI))
+	calleeABI, _ = abi.JSON(strings.NewReader(uniswapv2callee.Uniswapv2calleeABI))
+)
+
+// FlashSwap describes a flash swap, which optimistically sends the output amounts to To and then calls
+// uniswapV2Call(sender, amount0Out, amount1Out, Data) on it. The callee must repay the pair before the
+// call returns, see FlashRepayment and GetAmountIn
+type FlashSwap struct {
+	Pair       common.Address
+	Amount0Out *big.Int
+	Amount1Out *big.Int
+	To         common.Address
+	// Data is passed to the callee and must not be empty, otherwise the pair performs a regular swap
+	Data []byte
+}
+
+// Calldata returns the calldata of the pair's swap function for the flash swap
+func (f FlashSwap) Calldata() ([]byte, error) {
+	if len(f.Data) == 0 {
+		return nil, errors.New("flash swaps require callback data")
+	}
+	return pairABI.Pack("swap", f.Amount0Out, f.Amount1Out, f.To, f.Data)
+}
+
+// CalleeCalldata returns the calldata of the uniswapV2Call made by the pair to the callee, which is
+// useful for testing a callee contract directly
+func (f FlashSwap) CalleeCalldata(sender common.Address) ([]byte, error) {
+	return calleeABI.Pack("uniswapV2Call", sender, f.Amount0Out, f.Amount1Out, f.Data)
+}
+
+// Simulate executes the flash swap from the given sender via eth_call against the backend,
+// returning an error if it would revert, for example because the callee fails to repay the pair
+func (f FlashSwap) Simulate(ctx context.Context, backend bind.ContractCaller, from common.Address) error {
+	data, err := f.Calldata()
+	if err != nil {
+		return err
+	}
+	_, err = backend.CallContract(ctx, ethereum.CallMsg{From: from, To: &f.Pair, Data: data}, nil)
+	return err
+}
+
+// FlashRepayment returns the amount that must be repaid in the same token when borrowing amountOut,
+// which is amountOut plus the 0.3% fee charged on the repayment, rounded up
+func FlashRepayment(amountOut *big.Int) *big.Int {
+	// the pair requires amountIn * 997 >= amountOut * 1000
+	repayment := new(big.Int).Mul(amountOut, big.NewInt(1000))
+	repayment.Add(repayment, big.NewInt(996))
+	return repayment.Div(repayment, big.NewInt(997))
+}
+
+// GetAmountIn returns the minimum input amount of the other asset required to receive amountOut
+// from the given reserves, accounting for the 0.3% liquidity provider fee. This is the amount that
+// must be repaid in the other token when borrowing amountOut through a flash swap
+func GetAmountIn(amountOut, reserveIn, reserveOut *big.Int) *big.Int {
+	if amountOut.Sign() <= 0 || reserveIn.Sign() <= 0 || amountOut.Cmp(reserveOut) >= 0 {
+		return new(big.Int)
+	}
+	// amountIn = reserveIn * amountOut * 1000 / ((reserveOut - amountOut) * 997) + 1
+	numerator := new(big.Int).Mul(reserveIn, amountOut)
+	numerator.Mul(numerator, big.NewInt(1000))
+	denominator := new(big.Int).Sub(reserveOut, amountOut)
+	denominator.Mul(denominator, big.NewInt(997))
+	amountIn := numerator.Div(numerator, denominator)
+	return amountIn.Add(amountIn, big.NewInt(1))
+}
@@ -0,0 +1,142 @@
+package uniswap_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	uniswapv2pair "github.com/bonedaddy/unibot/bindings/uniswapv2/pair"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlashRepayment(t *testing.T) {
+	tests := []struct {
+		name      string
+		amountOut int64
+		want      int64
+	}{
+		{"exact", 997, 1000},
+		{"rounds-up", 1000, 1004},
+		{"zero", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, uniswap.FlashRepayment(big.NewInt(tt.amountOut)).Int64())
+		})
+	}
+}
+
+func TestGetAmountIn(t *testing.T) {
+	reserveIn, reserveOut := big.NewInt(2000000), big.NewInt(1000000)
+	for _, amountOut := range []int64{1, 1000, 123456, 999999} {
+		amountIn := uniswap.GetAmountIn(big.NewInt(amountOut), reserveIn, reserveOut)
+		// the input must buy at least amountOut, and one less must not
+		require.GreaterOrEqual(t, uniswap.GetAmountOut(amountIn, reserveIn, reserveOut).Int64(), amountOut)
+		less := new(big.Int).Sub(amountIn, big.NewInt(1))
+		require.Less(t, uniswap.GetAmountOut(less, reserveIn, reserveOut).Int64(), amountOut)
+	}
+	require.Zero(t, uniswap.GetAmountIn(reserveOut, reserveIn, reserveOut).Sign())
+}
+
+func TestFlashSwap(t *testing.T) {
+	h, err := harness.New()
+	require.NoError(t, err)
+	defer h.Close()
+	tokenA, err := h.DeployToken("Token A", "A", 18)
+	require.NoError(t, err)
+	tokenB, err := h.DeployToken("Token B", "B", 18)
+	require.NoError(t, err)
+	pair, err := h.DeployPair(tokenA, tokenB)
+	require.NoError(t, err)
+	token0, token1 := tokenA, tokenB
+	if new(big.Int).SetBytes(token0.Bytes()).Cmp(new(big.Int).SetBytes(token1.Bytes())) > 0 {
+		token0, token1 = token1, token0
+	}
+	reserve0, reserve1 := big.NewInt(1000000000), big.NewInt(2000000000)
+	require.NoError(t, h.AddLiquidity(pair, reserve0, reserve1))
+	caller, err := uniswapv2pair.NewUniswapv2pairCaller(pair, h.Backend)
+	require.NoError(t, err)
+	callee, err := h.DeployCallee()
+	require.NoError(t, err)
+	// the callee holds enough token0 to pay the fees, and enough token1 to buy the borrowed token0
+	require.NoError(t, h.Mint(token0, callee, big.NewInt(10000)))
+	require.NoError(t, h.Mint(token1, callee, big.NewInt(10000000)))
+
+	// the harness callee (harness/contracts/Callee.sol) expects abi.encode(token, amount) and repays amount of token
+	addressType, _ := abi.NewType("address", "", nil)
+	uintType, _ := abi.NewType("uint256", "", nil)
+	repay := func(token common.Address, amount *big.Int) []byte {
+		data, err := abi.Arguments{{Type: addressType}, {Type: uintType}}.Pack(token, amount)
+		require.NoError(t, err)
+		return data
+	}
+	borrowed := big.NewInt(1000000)
+	sameToken := uniswap.FlashRepayment(borrowed)
+	otherToken := uniswap.GetAmountIn(borrowed, reserve1, reserve0)
+	tests := []struct {
+		name      string
+		data      []byte
+		wantErr   bool
+		wantDelta [2]int64
+	}{
+		{"no-data", nil, true, [2]int64{}},
+		{"underpaid", repay(token0, new(big.Int).Sub(sameToken, big.NewInt(1))), true, [2]int64{}},
+		{"other-token-underpaid", repay(token1, new(big.Int).Sub(otherToken, big.NewInt(1))), true, [2]int64{}},
+		{"same-token", repay(token0, sameToken), false, [2]int64{borrowed.Int64() - sameToken.Int64(), 0}},
+		{"other-token", repay(token1, otherToken), false, [2]int64{borrowed.Int64(), -otherToken.Int64()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swap := uniswap.FlashSwap{
+				Pair:       pair,
+				Amount0Out: borrowed,
+				Amount1Out: big.NewInt(0),
+				To:         callee,
+				Data:       tt.data,
+			}
+			err := swap.Simulate(context.Background(), h.Backend, h.Auth.From)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			before0, err := h.BalanceOf(token0, callee)
+			require.NoError(t, err)
+			before1, err := h.BalanceOf(token1, callee)
+			require.NoError(t, err)
+			calldata, err := swap.Calldata()
+			require.NoError(t, err)
+			require.NoError(t, h.SendTransaction(pair, nil, calldata))
+			after0, err := h.BalanceOf(token0, callee)
+			require.NoError(t, err)
+			after1, err := h.BalanceOf(token1, callee)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDelta[0], new(big.Int).Sub(after0, before0).Int64())
+			require.Equal(t, tt.wantDelta[1], new(big.Int).Sub(after1, before1).Int64())
+			// the pair keeps what the callee paid and syncs its reserves to its balances
+			pairBalance0, err := h.BalanceOf(token0, pair)
+			require.NoError(t, err)
+			pairBalance1, err := h.BalanceOf(token1, pair)
+			require.NoError(t, err)
+			require.Equal(t, -tt.wantDelta[0], new(big.Int).Sub(pairBalance0, reserve0).Int64())
+			require.Equal(t, -tt.wantDelta[1], new(big.Int).Sub(pairBalance1, reserve1).Int64())
+			reserves, err := caller.GetReserves(nil)
+			require.NoError(t, err)
+			require.Equal(t, pairBalance0.String(), reserves.Reserve0.String())
+			require.Equal(t, pairBalance1.String(), reserves.Reserve1.String())
+			reserve0, reserve1 = pairBalance0, pairBalance1
+		})
+	}
+}
+
+func TestFlashSwapCalleeCalldata(t *testing.T) {
+	swap := uniswap.FlashSwap{Amount0Out: big.NewInt(1), Amount1Out: big.NewInt(2), Data: []byte{0x01}}
+	data, err := swap.CalleeCalldata(common.HexToAddress("0x01"))
+	require.NoError(t, err)
+	// uniswapV2Call(address,uint256,uint256,bytes)
+	require.Equal(t, []byte{0x10, 0xd1, 0xe8, 0x5c}, data[:4])
+}
@@ -73,6 +73,53 @@ func Quote(amount, reserve0, reserve1 *big.Int) *big.Int {
 	return res
 }
 
+// GetAmountOut returns the maximum output amount of the other asset when swapping amountIn
+// against the given reserves, accounting for the 0.3% liquidity provider fee.
+func GetAmountOut(amountIn, reserveIn, reserveOut *big.Int) *big.Int {
+	return GetAmountOutWithFee(amountIn, reserveIn, reserveOut, 30)
+}
+
+// GetAmountOutWithFee is like GetAmountOut but charges a fee of the given basis points, for use with
+// forks charging a different fee than uniswap.
+func GetAmountOutWithFee(amountIn, reserveIn, reserveOut *big.Int, fee int64) *big.Int {
+	if amountIn.Cmp(big.NewInt(0)) <= 0 ||
+		reserveIn.Cmp(big.NewInt(0)) <= 0 ||
+		reserveOut.Cmp(big.NewInt(0)) <= 0 {
+
+		return new(big.Int)
+	}
+
+	// amountInWithFee = amountIn * (10000 - fee)
+	// amountOut = amountInWithFee * reserveOut / (reserveIn * 10000 + amountInWithFee)
+	amountInWithFee := new(big.Int).Mul(amountIn, big.NewInt(10000-fee))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, big.NewInt(10000))
+	denominator.Add(denominator, amountInWithFee)
+	return numerator.Div(numerator, denominator)
+}
+
+// PriceImpact returns the price impact of swapping amountIn against the given reserves as a fraction,
+// ie 0.01 is a 1% impact. It is the difference between the execution price and the mid price, fee included.
+func PriceImpact(amountIn, reserveIn, reserveOut *big.Int) float64 {
+	return PriceImpactWithFee(amountIn, reserveIn, reserveOut, 30)
+}
+
+// PriceImpactWithFee is like PriceImpact but charges a fee of the given basis points, for use with
+// forks charging a different fee than uniswap.
+func PriceImpactWithFee(amountIn, reserveIn, reserveOut *big.Int, fee int64) float64 {
+	amountOut := GetAmountOutWithFee(amountIn, reserveIn, reserveOut, fee)
+	if amountOut.Sign() <= 0 {
+		return 0
+	}
+	// midPrice = reserveOut / reserveIn, executionPrice = amountOut / amountIn
+	// impact = 1 - executionPrice / midPrice = 1 - (amountOut * reserveIn) / (amountIn * reserveOut)
+	ratio, _ := new(big.Rat).SetFrac(
+		new(big.Int).Mul(amountOut, reserveIn),
+		new(big.Int).Mul(amountIn, reserveOut),
+	).Float64()
+	return 1 - ratio
+}
+
 func sortAddressess(tkn0, tkn1 common.Address) (common.Address, common.Address) {
 	token0Rep := big.NewInt(0).SetBytes(tkn0.Bytes())
 	token1Rep := big.NewInt(0).SetBytes(tkn1.Bytes())
@@ -252,3 +252,69 @@ func TestGetPathPairs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAmountOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		amountIn   *big.Int
+		reserveIn  *big.Int
+		reserveOut *big.Int
+		want       *big.Int
+	}{
+		{"zero-amount", big.NewInt(0), big.NewInt(1000), big.NewInt(1000), big.NewInt(0)},
+		{"zero-reserve", big.NewInt(10), big.NewInt(0), big.NewInt(1000), big.NewInt(0)},
+		// 1000 * 997 * 1000000 / (1000000 * 1000 + 1000 * 997)
+		{"small-swap", big.NewInt(1000), big.NewInt(1000000), big.NewInt(1000000), big.NewInt(996)},
+		// 100000 * 997 * 1000000 / (1000000 * 1000 + 100000 * 997)
+		{"large-swap", big.NewInt(100000), big.NewInt(1000000), big.NewInt(1000000), big.NewInt(90661)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetAmountOut(tt.amountIn, tt.reserveIn, tt.reserveOut); got.Cmp(tt.want) != 0 {
+				t.Errorf("GetAmountOut() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceImpact(t *testing.T) {
+	reserve := big.NewInt(1000000)
+	small := PriceImpact(big.NewInt(1000), reserve, reserve)
+	large := PriceImpact(big.NewInt(100000), reserve, reserve)
+	// small swaps should only pay the fee
+	if small < 0.003 || small > 0.005 {
+		t.Errorf("PriceImpact() = %v, want ~0.004", small)
+	}
+	if large <= small {
+		t.Errorf("PriceImpact() large swap %v should have more impact than small swap %v", large, small)
+	}
+	if got := PriceImpact(big.NewInt(0), reserve, reserve); got != 0 {
+		t.Errorf("PriceImpact() = %v, want 0", got)
+	}
+	// small swaps against deep reserves only pay the venue's fee
+	deep := big.NewInt(1000000000000)
+	if got := PriceImpactWithFee(big.NewInt(1000000), deep, deep, 25); got < 0.0025 || got > 0.0026 {
+		t.Errorf("PriceImpactWithFee() = %v, want ~0.0025", got)
+	}
+}
+
+func TestLogPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end uint64
+		want       [][2]uint64
+	}{
+		{"single", 10, 10, [][2]uint64{{10, 10}}},
+		{"within-page", 10, 14, [][2]uint64{{10, 14}}},
+		{"exact-pages", 0, 9, [][2]uint64{{0, 4}, {5, 9}}},
+		{"partial-page", 3, 14, [][2]uint64{{3, 7}, {8, 12}, {13, 14}}},
+		{"empty", 10, 9, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logPages(tt.start, tt.end, 5); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("logPages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package uniswap
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/bonedaddy/unibot/bindings/erc20"
+	uniswapv2router "github.com/bonedaddy/unibot/bindings/uniswapv2/router"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	routerABI, _ = abi.JSON(strings.NewReader(uniswapv2router.Uniswapv2routerABI))
+	erc20ABI, _  = abi.JSON(strings.NewReader(erc20.Erc20ABI))
+)
+
+// SwapParams describes an exact input swap through Router02
+type SwapParams struct {
+	// Path is the route taken by the swap, starting with the input token
+	Path         []common.Address
+	AmountIn     *big.Int
+	AmountOutMin *big.Int
+	To           common.Address
+	Deadline     time.Time
+	// ETHIn swaps native ETH rather than an ERC20, in which case Path must start with WETH
+	ETHIn bool
+}
+
+// SwapCalldata returns the Router02 calldata for the swap, using swapExactETHForTokens
+// when swapping ETH and swapExactTokensForTokens otherwise
+func SwapCalldata(params SwapParams) ([]byte, error) {
+	if len(params.Path) < 2 {
+		return nil, errors.New("not enough tokens for path")
+	}
+	deadline := big.NewInt(params.Deadline.Unix())
+	if params.ETHIn {
+		return routerABI.Pack("swapExactETHForTokens", params.AmountOutMin, params.Path, params.To, deadline)
+	}
+	return routerABI.Pack("swapExactTokensForTokens", params.AmountIn, params.AmountOutMin, params.Path, params.To, deadline)
+}
+
+// UnpackSwapAmounts decodes the amounts returned by a Router02 swap, where the last amount is the output
+func UnpackSwapAmounts(data []byte) ([]*big.Int, error) {
+	var amounts []*big.Int
+	if err := routerABI.UnpackIntoInterface(&amounts, "swapExactTokensForTokens", data); err != nil {
+		return nil, err
+	}
+	return amounts, nil
+}
+
+// ApproveCalldata returns the ERC20 calldata approving spender to transfer amount
+func ApproveCalldata(spender common.Address, amount *big.Int) ([]byte, error) {
+	return erc20ABI.Pack("approve", spender, amount)
+}
+
+// AmountOutMin returns the minimum amount out accepted for a quoted amount given the
+// allowed slippage in basis points
+func AmountOutMin(amountOut *big.Int, slippage int64) *big.Int {
+	min := new(big.Int).Mul(amountOut, big.NewInt(10000-slippage))
+	return min.Div(min, big.NewInt(10000))
+}
+
+// GetAmountsOut quotes an exact input swap along the path using the venue's Router02, returning
+// the amount received at each hop with the final amount being the output
+func (c *Client) GetAmountsOut(amountIn *big.Int, path []common.Address) ([]*big.Int, error) {
+	if len(path) < 2 {
+		return nil, errors.New("not enough tokens for path")
+	}
+	router, err := uniswapv2router.NewUniswapv2routerCaller(c.venue.Router, c.bc)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return router.GetAmountsOut(&bind.CallOpts{Context: ctx}, amountIn, path)
+}
+
+// SwapPath returns the path used to swap tokenIn for tokenOut, which is the pair itself when
+// it exists and a route through via otherwise
+func (c *Client) SwapPath(tokenIn, tokenOut, via common.Address) ([]common.Address, error) {
+	_, err := c.PairAddress(tokenIn, tokenOut)
+	if err == nil {
+		return []common.Address{tokenIn, tokenOut}, nil
+	}
+	if !errors.Is(err, ErrPairNotFound) || tokenIn == via || tokenOut == via {
+		return nil, err
+	}
+	if _, err := c.PairAddress(tokenIn, via); err != nil {
+		return nil, err
+	}
+	if _, err := c.PairAddress(via, tokenOut); err != nil {
+		return nil, err
+	}
+	return []common.Address{tokenIn, via, tokenOut}, nil
+}
@@ -0,0 +1,90 @@
+package uniswap
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestSwapCalldata(t *testing.T) {
+	path := []common.Address{
+		common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"),
+	}
+	tests := []struct {
+		name         string
+		params       SwapParams
+		wantSelector string
+		wantErr      bool
+	}{
+		{"tokens", SwapParams{Path: path, AmountIn: big.NewInt(100), AmountOutMin: big.NewInt(90), Deadline: time.Unix(1000, 0)}, "0x38ed1739", false},
+		{"eth", SwapParams{Path: path, AmountIn: big.NewInt(100), AmountOutMin: big.NewInt(90), Deadline: time.Unix(1000, 0), ETHIn: true}, "0x7ff36ab5", false},
+		{"short-path", SwapParams{Path: path[:1], AmountIn: big.NewInt(100), AmountOutMin: big.NewInt(90)}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := SwapCalldata(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SwapCalldata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := hexutil.Encode(data[:4]); got != tt.wantSelector {
+				t.Errorf("selector = %s, want %s", got, tt.wantSelector)
+			}
+		})
+	}
+}
+
+func TestApproveCalldata(t *testing.T) {
+	data, err := ApproveCalldata(Router02Address, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hexutil.Encode(data[:4]); got != "0x095ea7b3" {
+		t.Errorf("selector = %s, want 0x095ea7b3", got)
+	}
+	if !bytes.Equal(data[4+12:4+32], Router02Address.Bytes()) {
+		t.Errorf("spender = %x, want %s", data[4+12:4+32], Router02Address)
+	}
+}
+
+func TestUnpackSwapAmounts(t *testing.T) {
+	want := []*big.Int{big.NewInt(100), big.NewInt(250)}
+	data, err := routerABI.Methods["swapExactTokensForTokens"].Outputs.Pack(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnpackSwapAmounts(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Cmp(want[0]) != 0 || got[1].Cmp(want[1]) != 0 {
+		t.Errorf("UnpackSwapAmounts() = %v, want %v", got, want)
+	}
+}
+
+func TestAmountOutMin(t *testing.T) {
+	tests := []struct {
+		name      string
+		amountOut *big.Int
+		slippage  int64
+		want      *big.Int
+	}{
+		{"no-slippage", big.NewInt(10000), 0, big.NewInt(10000)},
+		{"half-percent", big.NewInt(10000), 50, big.NewInt(9950)},
+		{"rounds-down", big.NewInt(999), 50, big.NewInt(994)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AmountOutMin(tt.amountOut, tt.slippage); got.Cmp(tt.want) != 0 {
+				t.Errorf("AmountOutMin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
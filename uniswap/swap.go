@@ -0,0 +1,116 @@
+package uniswap
+
+import (
+	"context"
+	"math/big"
+
+	uniswapv2pair "github.com/bonedaddy/unibot/bindings/uniswapv2/pair"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Swap is a swap emitted by a pair with amounts expressed in the
+// order of the tokens the caller asked about rather than the sorted pair order
+type Swap struct {
+	Pair        common.Address
+	Sender      common.Address
+	To          common.Address
+	Amount0In   *big.Int
+	Amount1In   *big.Int
+	Amount0Out  *big.Int
+	Amount1Out  *big.Int
+	TxHash      common.Hash
+	TxIndex     uint
+	BlockHash   common.Hash
+	BlockNumber uint64
+	LogIndex    uint
+	Removed     bool
+}
+
+// IsBuy returns true if the swap sent token0 out of the pair, ie token0 was bought
+func (s *Swap) IsBuy() bool {
+	return s.Amount0Out.Cmp(big.NewInt(0)) > 0
+}
+
+// FilterSwaps returns all swaps on the token0/token1 pair between the start and end block.
+// If end is nil, swaps up until the latest block are returned
+func (c *Client) FilterSwaps(ctx context.Context, token0, token1 common.Address, start uint64, end *uint64) ([]*Swap, error) {
+	addr, err := c.PairAddress(token0, token1)
+	if err != nil {
+		return nil, err
+	}
+	filterer, err := uniswapv2pair.NewUniswapv2pairFilterer(addr, c.bc)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := filterer.FilterSwap(&bind.FilterOpts{Start: start, End: end, Context: ctx}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var swaps []*Swap
+	for iter.Next() {
+		swaps = append(swaps, newSwap(token0, token1, iter.Event))
+	}
+	return swaps, iter.Error()
+}
+
+// WatchSwaps subscribes to swaps on the token0/token1 pair, sending them to sink until
+// the returned subscription is unsubscribed or fails. Subscriptions require a websocket connection
+func (c *Client) WatchSwaps(ctx context.Context, token0, token1 common.Address, sink chan<- *Swap) (event.Subscription, error) {
+	addr, err := c.PairAddress(token0, token1)
+	if err != nil {
+		return nil, err
+	}
+	filterer, err := uniswapv2pair.NewUniswapv2pairFilterer(addr, c.bc)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan *uniswapv2pair.Uniswapv2pairSwap)
+	sub, err := filterer.WatchSwap(&bind.WatchOpts{Context: ctx}, events, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-events:
+				select {
+				case sink <- newSwap(token0, token1, ev):
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func newSwap(token0, token1 common.Address, ev *uniswapv2pair.Uniswapv2pairSwap) *Swap {
+	swap := &Swap{
+		Pair:        ev.Raw.Address,
+		Sender:      ev.Sender,
+		To:          ev.To,
+		Amount0In:   ev.Amount0In,
+		Amount1In:   ev.Amount1In,
+		Amount0Out:  ev.Amount0Out,
+		Amount1Out:  ev.Amount1Out,
+		TxHash:      ev.Raw.TxHash,
+		TxIndex:     ev.Raw.TxIndex,
+		BlockHash:   ev.Raw.BlockHash,
+		BlockNumber: ev.Raw.BlockNumber,
+		LogIndex:    ev.Raw.Index,
+		Removed:     ev.Raw.Removed,
+	}
+	// same as with reserves, the event amounts are given in sorted order
+	if stoken0, _ := sortAddressess(token0, token1); stoken0 != token0 {
+		swap.Amount0In, swap.Amount1In = swap.Amount1In, swap.Amount0In
+		swap.Amount0Out, swap.Amount1Out = swap.Amount1Out, swap.Amount0Out
+	}
+	return swap
+}
@@ -0,0 +1,250 @@
+package uniswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	uniswapv3pool "github.com/bonedaddy/unibot/bindings/uniswapv3/pool"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// V3FactoryAddress points to the uniswap v3 factory.
+var V3FactoryAddress = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+
+// V3FeeTiers are the fee tiers, in hundredths of a bip, that v3 pools may be deployed with
+var V3FeeTiers = []uint32{100, 500, 3000, 10000}
+
+// ErrInsufficientLiquidity is returned when a v3 swap can't be filled by the fetched ticks
+var ErrInsufficientLiquidity = errors.New("insufficient liquidity")
+
+const v3PoolInitCodeHash = "e34f199b19b2b4f47f68442619d555527d244f78a3297ea89325f843f87b8b54"
+
+// GenerateV3PoolAddress generates the v3 pool address for the given tokens and fee tier
+func GenerateV3PoolAddress(token0, token1 common.Address, fee uint32) common.Address {
+	token0, token1 = sortAddressess(token0, token1)
+	// the salt is keccak256(abi.encode(token0, token1, fee))
+	salt := make([]byte, 0, 96)
+	salt = append(salt, common.LeftPadBytes(token0.Bytes(), 32)...)
+	salt = append(salt, common.LeftPadBytes(token1.Bytes(), 32)...)
+	salt = append(salt, common.LeftPadBytes(big.NewInt(int64(fee)).Bytes(), 32)...)
+	var saltHash [32]byte
+	copy(saltHash[:], crypto.Keccak256(salt))
+	return crypto.CreateAddress2(V3FactoryAddress, saltHash, common.FromHex(v3PoolInitCodeHash))
+}
+
+// V3Tick is an initialized tick of a v3 pool
+type V3Tick struct {
+	Index        int
+	LiquidityNet *big.Int
+}
+
+// V3Pool is a snapshot of a v3 pool's state. Token0 and Token1 are in the pool's sorted order
+type V3Pool struct {
+	Address      common.Address
+	Token0       common.Address
+	Token1       common.Address
+	Fee          uint32
+	TickSpacing  int
+	SqrtPriceX96 *big.Int
+	Tick         int
+	Liquidity    *big.Int
+	// Ticks are the initialized ticks between TickLower and TickUpper, sorted by index
+	Ticks     []V3Tick
+	TickLower int
+	TickUpper int
+}
+
+// Price returns the price of token0 denominated in token1
+func (p *V3Pool) Price(decimals0, decimals1 int) float64 {
+	return SqrtPriceX96ToPrice(p.SqrtPriceX96, decimals0, decimals1)
+}
+
+// QuoteExactInput simulates swapping amountIn of token0 for token1 if zeroForOne, or token1 for token0
+// otherwise, walking the initialized ticks and returning the amount received
+func (p *V3Pool) QuoteExactInput(amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	remaining := new(big.Int).Set(amountIn)
+	amountOut := new(big.Int)
+	sqrtPrice := new(big.Int).Set(p.SqrtPriceX96)
+	liquidity := new(big.Int).Set(p.Liquidity)
+	tick := p.Tick
+	for remaining.Sign() > 0 {
+		next, tickIndex := p.nextInitializedTick(tick, zeroForOne)
+		sqrtTarget := GetSqrtRatioAtTick(next)
+		sqrtNext, in, out, fee := computeSwapStep(sqrtPrice, sqrtTarget, liquidity, remaining, int64(p.Fee))
+		remaining.Sub(remaining, in)
+		remaining.Sub(remaining, fee)
+		amountOut.Add(amountOut, out)
+		sqrtPrice = sqrtNext
+		if sqrtPrice.Cmp(sqrtTarget) != 0 {
+			// the swap was filled within the current tick range
+			break
+		}
+		if tickIndex < 0 {
+			// reached the edge of the known price range without filling the swap
+			return nil, ErrInsufficientLiquidity
+		}
+		// crossing a tick adds or removes the liquidity of positions starting or ending there
+		liquidityNet := p.Ticks[tickIndex].LiquidityNet
+		if zeroForOne {
+			liquidity.Sub(liquidity, liquidityNet)
+			tick = next - 1
+		} else {
+			liquidity.Add(liquidity, liquidityNet)
+			tick = next
+		}
+	}
+	return amountOut, nil
+}
+
+// nextInitializedTick returns the next initialized tick in the direction of the swap along with its
+// position in Ticks. When there are no more known initialized ticks, the edge of the fetched tick
+// range is returned with a position of -1
+func (p *V3Pool) nextInitializedTick(tick int, zeroForOne bool) (int, int) {
+	if zeroForOne {
+		// the largest initialized tick less than or equal to the current tick
+		i := sort.Search(len(p.Ticks), func(i int) bool { return p.Ticks[i].Index > tick }) - 1
+		if i >= 0 {
+			return p.Ticks[i].Index, i
+		}
+		if p.TickLower < MinTick {
+			return MinTick, -1
+		}
+		return p.TickLower, -1
+	}
+	// the smallest initialized tick greater than the current tick
+	i := sort.Search(len(p.Ticks), func(i int) bool { return p.Ticks[i].Index > tick })
+	if i < len(p.Ticks) {
+		return p.Ticks[i].Index, i
+	}
+	if p.TickUpper > MaxTick {
+		return MaxTick, -1
+	}
+	return p.TickUpper, -1
+}
+
+// GetV3Pool fetches the state of the v3 pool for the given tokens and fee tier. Initialized ticks
+// are fetched from tickWords bitmap words either side of the current tick, where each word covers
+// 256 * tickSpacing ticks. If tickWords is 0 no ticks are fetched and the pool can't be used for quotes
+func (c *Client) GetV3Pool(token0, token1 common.Address, fee uint32, tickWords int) (*V3Pool, error) {
+	addr := GenerateV3PoolAddress(token0, token1, fee)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	code, err := c.bc.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("%w: %s/%s fee %d", ErrPairNotFound, token0, token1, fee)
+	}
+	caller, err := uniswapv3pool.NewUniswapv3poolCaller(addr, c.bc)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	slot0, err := caller.Slot0(opts)
+	if err != nil {
+		return nil, err
+	}
+	liquidity, err := caller.Liquidity(opts)
+	if err != nil {
+		return nil, err
+	}
+	spacing, err := caller.TickSpacing(opts)
+	if err != nil {
+		return nil, err
+	}
+	stoken0, stoken1 := sortAddressess(token0, token1)
+	pool := &V3Pool{
+		Address:      addr,
+		Token0:       stoken0,
+		Token1:       stoken1,
+		Fee:          fee,
+		TickSpacing:  int(spacing.Int64()),
+		SqrtPriceX96: slot0.SqrtPriceX96,
+		Tick:         int(slot0.Tick.Int64()),
+		Liquidity:    liquidity,
+	}
+	if tickWords <= 0 {
+		pool.TickLower, pool.TickUpper = pool.Tick, pool.Tick
+		return pool, nil
+	}
+	// ticks are stored in a bitmap of compressed ticks (tick / tickSpacing), 256 per word
+	compressed := pool.Tick / pool.TickSpacing
+	if pool.Tick < 0 && pool.Tick%pool.TickSpacing != 0 {
+		compressed--
+	}
+	word := compressed >> 8
+	for w := word - tickWords; w <= word+tickWords; w++ {
+		bitmap, err := caller.TickBitmap(opts, int16(w))
+		if err != nil {
+			return nil, err
+		}
+		for bit := 0; bit < 256; bit++ {
+			if bitmap.Bit(bit) == 0 {
+				continue
+			}
+			index := ((w << 8) + bit) * pool.TickSpacing
+			info, err := caller.Ticks(opts, big.NewInt(int64(index)))
+			if err != nil {
+				return nil, err
+			}
+			pool.Ticks = append(pool.Ticks, V3Tick{Index: index, LiquidityNet: info.LiquidityNet})
+		}
+	}
+	pool.TickLower = ((word - tickWords) << 8) * pool.TickSpacing
+	pool.TickUpper = (((word + tickWords + 1) << 8) - 1) * pool.TickSpacing
+	return pool, nil
+}
+
+// GetV3ExchangeAmount returns the amount of tokenOut received when swapping amountIn of tokenIn
+// through the v3 pool with the given fee tier
+func (c *Client) GetV3ExchangeAmount(amountIn *big.Int, tokenIn, tokenOut common.Address, fee uint32) (*big.Int, error) {
+	pool, err := c.GetV3Pool(tokenIn, tokenOut, fee, 2)
+	if err != nil {
+		return nil, err
+	}
+	return pool.QuoteExactInput(amountIn, tokenIn == pool.Token0)
+}
+
+// VenueQuote is the amount received when swapping through a single venue
+type VenueQuote struct {
+	Venue     string
+	AmountOut *big.Int
+}
+
+// CompareVenues quotes swapping amountIn of tokenIn for tokenOut through the v2 pair and every
+// v3 fee tier, returning the quotes sorted best first. Venues without a deployed pool are skipped
+func (c *Client) CompareVenues(amountIn *big.Int, tokenIn, tokenOut common.Address) ([]VenueQuote, error) {
+	var quotes []VenueQuote
+	if reserves, err := c.GetReserves(tokenIn, tokenOut); err == nil {
+		quotes = append(quotes, VenueQuote{
+			Venue:     "v2",
+			AmountOut: GetAmountOut(amountIn, reserves.Reserve0, reserves.Reserve1),
+		})
+	} else if !errors.Is(err, ErrPairNotFound) {
+		return nil, err
+	}
+	for _, fee := range V3FeeTiers {
+		amountOut, err := c.GetV3ExchangeAmount(amountIn, tokenIn, tokenOut, fee)
+		if err != nil {
+			if errors.Is(err, ErrPairNotFound) || errors.Is(err, ErrInsufficientLiquidity) {
+				continue
+			}
+			return nil, err
+		}
+		quotes = append(quotes, VenueQuote{Venue: fmt.Sprintf("v3-%d", fee), AmountOut: amountOut})
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("%w: %s/%s", ErrPairNotFound, tokenIn, tokenOut)
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		return quotes[i].AmountOut.Cmp(quotes[j].AmountOut) > 0
+	})
+	return quotes, nil
+}
@@ -0,0 +1,102 @@
+package uniswap
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	if got := GetSqrtRatioAtTick(0); got.Cmp(Q96) != 0 {
+		t.Errorf("GetSqrtRatioAtTick(0) = %v, want %v", got, Q96)
+	}
+	if got := GetSqrtRatioAtTick(MinTick); got.Cmp(MinSqrtRatio) != 0 {
+		t.Errorf("GetSqrtRatioAtTick(MinTick) = %v, want %v", got, MinSqrtRatio)
+	}
+	if got := GetSqrtRatioAtTick(MaxTick); got.Cmp(MaxSqrtRatio) != 0 {
+		t.Errorf("GetSqrtRatioAtTick(MaxTick) = %v, want %v", got, MaxSqrtRatio)
+	}
+	// every bit of the tick should be covered by the precomputed ratios
+	for _, tick := range []int{1, -1, 2, 50, -50, 1000, -1000, 16383, -16383, 200000, -200000, 524287} {
+		got, _ := new(big.Rat).SetFrac(GetSqrtRatioAtTick(tick), Q96).Float64()
+		want := math.Sqrt(math.Pow(1.0001, float64(tick)))
+		if math.Abs(got-want)/want > 1e-9 {
+			t.Errorf("GetSqrtRatioAtTick(%d) = %v, want %v", tick, got, want)
+		}
+	}
+}
+
+func TestGenerateV3PoolAddress(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	tests := []struct {
+		name string
+		fee  uint32
+		want common.Address
+	}{
+		{"usdc-weth-500", 500, common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")},
+		{"usdc-weth-3000", 3000, common.HexToAddress("0x8ad599c3A0ff1De082011EFDDc58f1908eb6e6D8")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateV3PoolAddress(weth, usdc, tt.fee); got != tt.want {
+				t.Errorf("GenerateV3PoolAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSqrtPriceX96ToPrice(t *testing.T) {
+	if got := SqrtPriceX96ToPrice(Q96, 18, 18); got != 1 {
+		t.Errorf("SqrtPriceX96ToPrice() = %v, want 1", got)
+	}
+	// a sqrt price of 2 is a price of 4, shifted by the decimals difference
+	sqrtPrice := new(big.Int).Mul(Q96, big.NewInt(2))
+	if got := SqrtPriceX96ToPrice(sqrtPrice, 18, 6); math.Abs(got-4e12) > 1 {
+		t.Errorf("SqrtPriceX96ToPrice() = %v, want 4e12", got)
+	}
+}
+
+// newFullRangePool returns a pool at price 1 with a single full range position,
+// which behaves the same as a v2 pair with reserves equal to the liquidity
+func newFullRangePool(liquidity *big.Int) *V3Pool {
+	return &V3Pool{
+		Fee:          3000,
+		TickSpacing:  60,
+		SqrtPriceX96: new(big.Int).Set(Q96),
+		Tick:         0,
+		Liquidity:    liquidity,
+		Ticks: []V3Tick{
+			{Index: -887220, LiquidityNet: liquidity},
+			{Index: 887220, LiquidityNet: new(big.Int).Neg(liquidity)},
+		},
+		TickLower: MinTick,
+		TickUpper: MaxTick,
+	}
+}
+
+func TestV3PoolQuoteExactInput(t *testing.T) {
+	liquidity, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	pool := newFullRangePool(liquidity)
+	amountIn, _ := new(big.Int).SetString("10000000000000000000", 10)
+	for _, zeroForOne := range []bool{true, false} {
+		got, err := pool.QuoteExactInput(amountIn, zeroForOne)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := GetAmountOut(amountIn, liquidity, liquidity)
+		diff := new(big.Int).Sub(got, want)
+		if diff.CmpAbs(big.NewInt(2)) > 0 {
+			t.Errorf("QuoteExactInput(zeroForOne=%v) = %v, want %v", zeroForOne, got, want)
+		}
+	}
+	// a pool with unknown ticks beyond the fetched range can't fill large swaps
+	pool.Ticks = nil
+	pool.TickLower, pool.TickUpper = -60, 60
+	if _, err := pool.QuoteExactInput(amountIn, true); !errors.Is(err, ErrInsufficientLiquidity) {
+		t.Errorf("QuoteExactInput() err = %v, want %v", err, ErrInsufficientLiquidity)
+	}
+}
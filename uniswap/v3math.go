@@ -0,0 +1,190 @@
+package uniswap
+
+import (
+	"math/big"
+)
+
+// This file ports the subset of the uniswap v3 core libraries (TickMath, SqrtPriceMath and SwapMath)
+// needed to simulate exact input swaps. big.Int is used in place of the fixed size solidity
+// integers, so the overflow handling of the original libraries is not required.
+
+const (
+	// MinTick is the minimum tick that may be used by a v3 pool
+	MinTick = -887272
+	// MaxTick is the maximum tick that may be used by a v3 pool
+	MaxTick = 887272
+)
+
+var (
+	// Q96 is 2^96, the fixed point resolution of sqrtPriceX96
+	Q96 = new(big.Int).Lsh(big.NewInt(1), 96)
+	// MinSqrtRatio is the sqrt price at MinTick
+	MinSqrtRatio, _ = new(big.Int).SetString("4295128739", 10)
+	// MaxSqrtRatio is the sqrt price at MaxTick
+	MaxSqrtRatio, _ = new(big.Int).SetString("1461446703485210103287273052203988822378723970342", 10)
+
+	maxUint256   = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	q32          = new(big.Int).Lsh(big.NewInt(1), 32)
+	feeDenom     = big.NewInt(1000000)
+	tickRatioMul = []string{
+		"fff97272373d413259a46990580e213a",
+		"fff2e50f5f656932ef12357cf3c7fdcc",
+		"ffe5caca7e10e4e61c3624eaa0941cd0",
+		"ffcb9843d60f6159c9db58835c926644",
+		"ff973b41fa98c081472e6896dfb254c0",
+		"ff2ea16466c96a3843ec78b326b52861",
+		"fe5dee046a99a2a811c461f1969c3053",
+		"fcbe86c7900a88aedcffc83b479aa3a4",
+		"f987a7253ac413176f2b074cf7815e54",
+		"f3392b0822b70005940c7a398e4b70f3",
+		"e7159475a2c29b7443b29c7fa6e889d9",
+		"d097f3bdfd2022b8845ad8f792aa5825",
+		"a9f746462d870fdf8a65dc1f90e061e5",
+		"70d869a156d2a1b890bb3df62baf32f7",
+		"31be135f97d08fd981231505542fcfa6",
+		"9aa508b5b7a84e1c677de54f3e99bc9",
+		"5d6af8dedb81196699c329225ee604",
+		"2216e584f5fa1ea926041bedfe98",
+		"48a170391f7dc42444e8fa2",
+	}
+)
+
+func hexBig(s string) *big.Int {
+	v, _ := new(big.Int).SetString(s, 16)
+	return v
+}
+
+// GetSqrtRatioAtTick returns sqrt(1.0001^tick) * 2^96
+func GetSqrtRatioAtTick(tick int) *big.Int {
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	ratio := new(big.Int).Lsh(big.NewInt(1), 128)
+	if absTick&0x1 != 0 {
+		ratio = hexBig("fffcb933bd6fad37aa2d162d1a594001")
+	}
+	for i, mul := range tickRatioMul {
+		if absTick&(0x2<<uint(i)) != 0 {
+			ratio.Mul(ratio, hexBig(mul))
+			ratio.Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio = new(big.Int).Div(maxUint256, ratio)
+	}
+	// round up when converting from Q128.128 to Q64.96
+	sqrtPriceX96 := new(big.Int).Rsh(ratio, 32)
+	if new(big.Int).Mod(ratio, q32).Sign() != 0 {
+		sqrtPriceX96.Add(sqrtPriceX96, big.NewInt(1))
+	}
+	return sqrtPriceX96
+}
+
+// SqrtPriceX96ToPrice converts a sqrtPriceX96 into the price of token0 denominated in token1,
+// adjusting for the decimals of both tokens
+func SqrtPriceX96ToPrice(sqrtPriceX96 *big.Int, decimals0, decimals1 int) float64 {
+	// price = sqrtPriceX96^2 / 2^192 * 10^decimals0 / 10^decimals1
+	num := new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96)
+	num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals0)), nil))
+	den := new(big.Int).Lsh(big.NewInt(1), 192)
+	den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals1)), nil))
+	price, _ := new(big.Rat).SetFrac(num, den).Float64()
+	return price
+}
+
+func mulDiv(a, b, denominator *big.Int) *big.Int {
+	return new(big.Int).Div(new(big.Int).Mul(a, b), denominator)
+}
+
+func mulDivRoundingUp(a, b, denominator *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	result, rem := new(big.Int).QuoRem(product, denominator, new(big.Int))
+	if rem.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return result
+}
+
+func divRoundingUp(a, b *big.Int) *big.Int {
+	result, rem := new(big.Int).QuoRem(a, b, new(big.Int))
+	if rem.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return result
+}
+
+// getAmount0Delta returns the amount of token0 between two prices for the given liquidity
+func getAmount0Delta(sqrtA, sqrtB, liquidity *big.Int, roundUp bool) *big.Int {
+	if sqrtA.Cmp(sqrtB) > 0 {
+		sqrtA, sqrtB = sqrtB, sqrtA
+	}
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	numerator2 := new(big.Int).Sub(sqrtB, sqrtA)
+	if roundUp {
+		return divRoundingUp(mulDivRoundingUp(numerator1, numerator2, sqrtB), sqrtA)
+	}
+	return new(big.Int).Div(mulDiv(numerator1, numerator2, sqrtB), sqrtA)
+}
+
+// getAmount1Delta returns the amount of token1 between two prices for the given liquidity
+func getAmount1Delta(sqrtA, sqrtB, liquidity *big.Int, roundUp bool) *big.Int {
+	if sqrtA.Cmp(sqrtB) > 0 {
+		sqrtA, sqrtB = sqrtB, sqrtA
+	}
+	diff := new(big.Int).Sub(sqrtB, sqrtA)
+	if roundUp {
+		return mulDivRoundingUp(liquidity, diff, Q96)
+	}
+	return mulDiv(liquidity, diff, Q96)
+}
+
+// getNextSqrtPriceFromInput returns the price after adding amountIn of token0 (zeroForOne) or token1
+func getNextSqrtPriceFromInput(sqrtPrice, liquidity, amountIn *big.Int, zeroForOne bool) *big.Int {
+	if zeroForOne {
+		// sqrtPrice * liquidity / (liquidity + amountIn * sqrtPrice), rounding up
+		numerator1 := new(big.Int).Lsh(liquidity, 96)
+		denominator := new(big.Int).Add(numerator1, new(big.Int).Mul(amountIn, sqrtPrice))
+		return mulDivRoundingUp(numerator1, sqrtPrice, denominator)
+	}
+	// sqrtPrice + amountIn / liquidity, rounding down
+	quotient := new(big.Int).Div(new(big.Int).Lsh(amountIn, 96), liquidity)
+	return quotient.Add(quotient, sqrtPrice)
+}
+
+// computeSwapStep computes the result of swapping amountRemaining (exact input) within a single
+// tick range, returning the next sqrt price, the amount in and out, and the fee paid
+func computeSwapStep(sqrtCurrent, sqrtTarget, liquidity, amountRemaining *big.Int, feePips int64) (sqrtNext, amountIn, amountOut, feeAmount *big.Int) {
+	zeroForOne := sqrtCurrent.Cmp(sqrtTarget) >= 0
+	fee := big.NewInt(feePips)
+	amountRemainingLessFee := mulDiv(amountRemaining, new(big.Int).Sub(feeDenom, fee), feeDenom)
+	if zeroForOne {
+		amountIn = getAmount0Delta(sqrtTarget, sqrtCurrent, liquidity, true)
+	} else {
+		amountIn = getAmount1Delta(sqrtCurrent, sqrtTarget, liquidity, true)
+	}
+	if amountRemainingLessFee.Cmp(amountIn) >= 0 {
+		sqrtNext = sqrtTarget
+	} else {
+		sqrtNext = getNextSqrtPriceFromInput(sqrtCurrent, liquidity, amountRemainingLessFee, zeroForOne)
+	}
+	max := sqrtNext.Cmp(sqrtTarget) == 0
+	if zeroForOne {
+		if !max {
+			amountIn = getAmount0Delta(sqrtNext, sqrtCurrent, liquidity, true)
+		}
+		amountOut = getAmount1Delta(sqrtNext, sqrtCurrent, liquidity, false)
+	} else {
+		if !max {
+			amountIn = getAmount1Delta(sqrtCurrent, sqrtNext, liquidity, true)
+		}
+		amountOut = getAmount0Delta(sqrtCurrent, sqrtNext, liquidity, false)
+	}
+	if !max {
+		// the remainder of the input after the swap is taken as the fee
+		feeAmount = new(big.Int).Sub(amountRemaining, amountIn)
+	} else {
+		feeAmount = mulDivRoundingUp(amountIn, fee, new(big.Int).Sub(feeDenom, fee))
+	}
+	return sqrtNext, amountIn, amountOut, feeAmount
+}
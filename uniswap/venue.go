@@ -0,0 +1,58 @@
+package uniswap
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Venue is an exchange running a fork of the uniswap v2 contracts. Pairs on a venue
+// are deployed by its factory at addresses derived from the pair init code hash
+type Venue struct {
+	Name         string
+	Factory      common.Address
+	Router       common.Address
+	InitCodeHash common.Hash
+	// Fee is the swap fee charged by the venue's pairs in basis points
+	Fee int64
+}
+
+var (
+	// UniswapV2 is the uniswap v2 exchange
+	UniswapV2 = Venue{
+		Name:         "uniswap",
+		Factory:      FactoryAddress,
+		Router:       Router02Address,
+		InitCodeHash: common.HexToHash(pairAddressSuffix),
+		Fee:          30,
+	}
+	// SushiSwap is the sushiswap exchange
+	SushiSwap = Venue{
+		Name:         "sushiswap",
+		Factory:      common.HexToAddress("0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac"),
+		Router:       common.HexToAddress("0xd9e1cE17f2641f24aE83637ab66a2cca9C378B9F"),
+		InitCodeHash: common.HexToHash("e18a34eb0e04b04f7a0ac29a6e80748dca96319b42c520076c7b9ee8bf1b3b8f"),
+		Fee:          30,
+	}
+	// Venues are the known mainnet venues
+	Venues = []Venue{UniswapV2, SushiSwap}
+)
+
+// LookupVenue returns the known venue with the given name
+func LookupVenue(name string) (Venue, bool) {
+	for _, venue := range Venues {
+		if strings.EqualFold(venue.Name, name) {
+			return venue, true
+		}
+	}
+	return Venue{}, false
+}
+
+// PairAddress generates the address of the venue's pair for the given tokens
+func (v Venue) PairAddress(token0, token1 common.Address) common.Address {
+	token0, token1 = sortAddressess(token0, token1)
+	var salt [32]byte
+	copy(salt[:], crypto.Keccak256(append(token0.Bytes(), token1.Bytes()...)))
+	return crypto.CreateAddress2(v.Factory, salt, v.InitCodeHash.Bytes())
+}
@@ -2,10 +2,12 @@ package utils
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -38,6 +40,29 @@ func IsValidAddress(iaddress interface{}) bool {
 	}
 }
 
+// IsChecksumAddress validates the EIP-55 checksum of a hex address. Addresses in a single
+// case carry no checksum and are accepted
+func IsChecksumAddress(address string) bool {
+	if !IsValidAddress(address) {
+		return false
+	}
+	digits := address[2:]
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return true
+	}
+	return common.HexToAddress(address).Hex() == address
+}
+
+// IsENSName reports whether the string looks like an ENS name such as vitalik.eth, rather than a
+// hex address. Whether the name is registered can only be checked by resolving it
+func IsENSName(name string) bool {
+	return ensNameRegexp.MatchString(name)
+}
+
+// ensNameRegexp matches dot separated labels ending in an alphabetic top level domain, which
+// tells names apart from decimal amounts and pair names
+var ensNameRegexp = regexp.MustCompile(`^([^\s./]+\.)+[a-zA-Z]{2,}$`)
+
 // IsZeroAddress validate if it's a 0 address
 func IsZeroAddress(iaddress interface{}) bool {
 	var address common.Address
@@ -97,6 +122,23 @@ func ToWei(iamount interface{}, decimals int) *big.Int {
 	return wei
 }
 
+// ParseAmount parses a decimal amount of a token with the given decimals into its smallest unit.
+// Unlike ToWei malformed amounts are rejected, as are amounts that aren't positive or have more
+// decimal places than the token
+func ParseAmount(amount string, decimals int) (*big.Int, error) {
+	value, err := decimal.NewFromString(strings.TrimSpace(amount))
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if value.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount %q: must be positive", amount)
+	}
+	if !value.Equal(value.Truncate(int32(decimals))) {
+		return nil, fmt.Errorf("invalid amount %q: the token only has %d decimals", amount, decimals)
+	}
+	return value.Shift(int32(decimals)).BigInt(), nil
+}
+
 // CalcGasCost calculate gas cost given gas limit (units) and gas price (wei)
 func CalcGasCost(gasLimit uint64, gasPrice *big.Int) *big.Int {
 	gasLimitBig := big.NewInt(int64(gasLimit))
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals int
+		want     string
+		wantErr  bool
+	}{
+		{"whole", "2", 18, "2000000000000000000", false},
+		{"fraction", "1.5", 6, "1500000", false},
+		{"all decimals", "0.000001", 6, "1", false},
+		{"trailing zeros", "1.500000000", 6, "1500000", false},
+		{"comma", "1,5", 18, "", true},
+		{"empty", "", 18, "", true},
+		{"zero", "0", 18, "", true},
+		{"negative", "-1", 18, "", true},
+		{"too many decimals", "0.0000001", 6, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.amount, tt.decimals)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
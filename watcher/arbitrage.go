@@ -0,0 +1,233 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/uniswap"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ArbitrageDetector compares every watched pair across the configured venues each tick, recording
+// round trips that remain profitable after gas and optionally posting them to discord.
+// It is purely analytical and never executes trades
+type ArbitrageDetector struct {
+	wg     *sync.WaitGroup
+	bc     *bclient.Client
+	db     *db.Database
+	s      *discordgo.Session
+	cfg    discord.Arbitrage
+	venues []uniswap.Venue
+	// pairs are ordered tokenIn, token
+	pairs  []uniswap.Pair
+	ctx    context.Context
+	cancel context.CancelFunc
+	period time.Duration
+	// open tracks the pairs with an opportunity as of the last tick so each is posted once
+	open map[uniswap.Pair]bool
+}
+
+// NewArbitrageDetector returns a detector comparing the watched pairs across the venues of the
+// client's network, where token1 of each watcher is the token round trips start and end in
+func NewArbitrageDetector(ctx context.Context, bc *bclient.Client, db *db.Database, cfg discord.Arbitrage, watchers []discord.Watcher, tick time.Duration) (*ArbitrageDetector, error) {
+	net := bc.Network()
+	venues := net.DEXes
+	if len(cfg.Venues) > 0 {
+		venues = make([]uniswap.Venue, 0, len(cfg.Venues))
+		for _, name := range cfg.Venues {
+			venue, ok := net.LookupDEX(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown venue %s on %s", name, net.Name)
+			}
+			venues = append(venues, venue)
+		}
+	}
+	if len(venues) < 2 {
+		return nil, fmt.Errorf("arbitrage requires at least two venues but %s has %d", net.Name, len(venues))
+	}
+	if cfg.GasLimit == 0 {
+		cfg.GasLimit = 300000
+	}
+	pairs := make([]uniswap.Pair, 0, len(watchers))
+	for _, watch := range watchers {
+		pairs = append(pairs, uniswap.Pair{
+			Token0: common.HexToAddress(watch.Token1Address),
+			Token1: common.HexToAddress(watch.Token0Address),
+		})
+	}
+	var dg *discordgo.Session
+	if cfg.DiscordToken != "" {
+		var err error
+		if dg, err = discordgo.New("Bot " + cfg.DiscordToken); err != nil {
+			return nil, err
+		}
+		if err := dg.Open(); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &ArbitrageDetector{
+		wg:     &sync.WaitGroup{},
+		bc:     bc,
+		db:     db,
+		s:      dg,
+		cfg:    cfg,
+		venues: venues,
+		pairs:  pairs,
+		ctx:    ctx,
+		cancel: cancel,
+		period: tick,
+		open:   make(map[uniswap.Pair]bool),
+	}, nil
+}
+
+// Start begins comparing the watched pairs every tick
+func (a *ArbitrageDetector) Start() {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(a.period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-ticker.C:
+				for _, pair := range a.pairs {
+					if err := a.check(pair); err != nil {
+						log.Printf("failed to check arbitrage for tokenIn: %s token: %s - %s\n", pair.Token0, pair.Token1, err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the detector and terminates the discord session if any
+func (a *ArbitrageDetector) Stop() {
+	a.cancel()
+	a.wg.Wait()
+	if a.s != nil {
+		a.s.Close()
+	}
+}
+
+func (a *ArbitrageDetector) check(pair uniswap.Pair) error {
+	arb, err := a.bc.Uniswap().FindArbitrage(pair.Token0, pair.Token1, a.venues)
+	if err != nil {
+		return err
+	}
+	if arb == nil {
+		a.open[pair] = false
+		return nil
+	}
+	gasCost, err := a.gasCost(pair.Token0)
+	if err != nil {
+		return err
+	}
+	netProfit := new(big.Int).Sub(arb.Profit, gasCost)
+	if netProfit.Sign() <= 0 {
+		a.open[pair] = false
+		return nil
+	}
+	head, err := a.bc.CurrentHeader()
+	if err != nil {
+		return err
+	}
+	tkn, err := a.bc.TokenInfo(pair.Token0)
+	if err != nil {
+		return err
+	}
+	usdProfit, err := a.bc.USDValue(pair.Token0, netProfit)
+	if err != nil {
+		return err
+	}
+	record := &db.Arbitrage{
+		ChainID:     a.bc.Network().ChainID,
+		BlockNumber: head.Number.Uint64(),
+		BlockHash:   head.Hash().String(),
+		TokenIn:     pair.Token0.String(),
+		Token:       pair.Token1.String(),
+		BuyVenue:    arb.Buy.Name,
+		SellVenue:   arb.Sell.Name,
+	}
+	record.AmountIn, _ = utils.ToDecimal(arb.AmountIn, tkn.Decimals).Float64()
+	record.Profit, _ = utils.ToDecimal(arb.Profit, tkn.Decimals).Float64()
+	record.GasCost, _ = utils.ToDecimal(gasCost, tkn.Decimals).Float64()
+	record.NetProfit, _ = utils.ToDecimal(netProfit, tkn.Decimals).Float64()
+	record.USDProfit, _ = utils.ToDecimal(usdProfit, 18).Float64()
+	log.Printf(
+		"arbitrage tokenIn: %s token: %s - buy on %s sell on %s amountIn: %v net profit: %v (%.2f USD)",
+		pair.Token0, pair.Token1, arb.Buy.Name, arb.Sell.Name, record.AmountIn, record.NetProfit, record.USDProfit,
+	)
+	if err := a.db.RecordArbitrage(record); err != nil {
+		return err
+	}
+	wasOpen := a.open[pair]
+	a.open[pair] = true
+	if a.s == nil || wasOpen || record.USDProfit < a.cfg.MinProfitUSD {
+		return nil
+	}
+	_, err = a.s.ChannelMessageSendEmbed(a.cfg.ChannelID, renderArbitrageEmbed(tkn, record))
+	return err
+}
+
+// gasCost returns the estimated cost of executing a round trip denominated in tokenIn
+func (a *ArbitrageDetector) gasCost(tokenIn common.Address) (*big.Int, error) {
+	gasPrice, err := a.bc.GasPrice()
+	if err != nil {
+		return nil, err
+	}
+	cost := utils.CalcGasCost(a.cfg.GasLimit, gasPrice)
+	wrapped := a.bc.Network().WrappedNative
+	if tokenIn == wrapped {
+		return cost, nil
+	}
+	return a.bc.ExchangeAmount(cost, wrapped.String(), tokenIn.String())
+}
+
+// renderArbitrageEmbed renders the embed announcing an arbitrage opportunity
+func renderArbitrageEmbed(tkn *bclient.Token, arb *db.Arbitrage) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Type:      "rich",
+		Title:     fmt.Sprintf("Arbitrage %s -> %s", arb.BuyVenue, arb.SellVenue),
+		Timestamp: time.Now().Format(time.RFC3339),
+		Color:     0xffa500,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Pair",
+				Value:  arb.Token + "/" + arb.TokenIn,
+				Inline: false,
+			},
+			{
+				Name:   "Amount In",
+				Value:  fmt.Sprintf("%.4f %s", arb.AmountIn, tkn.Symbol),
+				Inline: true,
+			},
+			{
+				Name:   "Gas Cost",
+				Value:  fmt.Sprintf("%.4f %s", arb.GasCost, tkn.Symbol),
+				Inline: true,
+			},
+			{
+				Name:   "Net Profit",
+				Value:  fmt.Sprintf("%.4f %s ($%.2f)", arb.NetProfit, tkn.Symbol, arb.USDProfit),
+				Inline: true,
+			},
+			{
+				Name:   "Block",
+				Value:  fmt.Sprint(arb.BlockNumber),
+				Inline: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package watcher
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/utils"
+)
+
+// DepthRecorder periodically records the liquidity depth of the watched uniswap pairs
+// so that liquidity can be graphed over time
+type DepthRecorder struct {
+	wg     *sync.WaitGroup
+	bc     *bclient.Client
+	db     *db.Database
+	ctx    context.Context
+	cancel context.CancelFunc
+	period time.Duration
+	items  []WatchItem
+}
+
+// NewDepthRecorder returns a recorder for every watcher priced by uniswap v2 pairs
+func NewDepthRecorder(ctx context.Context, bc *bclient.Client, db *db.Database, watchers []discord.Watcher, tick time.Duration) *DepthRecorder {
+	items := make([]WatchItem, 0, len(watchers))
+	for _, watch := range watchers {
+		switch strings.ToLower(watch.Source) {
+		case "", "uniswap", "median":
+			items = append(items, WatchItem{Token0: watch.Token0Address, Token1: watch.Token1Address})
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &DepthRecorder{&sync.WaitGroup{}, bc, db, ctx, cancel, tick, items}
+}
+
+// Start begins recording depth every tick
+func (d *DepthRecorder) Start() {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(d.period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.ctx.Done():
+				return
+			case <-ticker.C:
+				for _, item := range d.items {
+					if err := d.record(item); err != nil {
+						log.Printf("failed to record depth for token0: %s token1: %s - %s\n", item.Token0, item.Token1, err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops recording depth
+func (d *DepthRecorder) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *DepthRecorder) record(item WatchItem) error {
+	depth, err := d.bc.Depth(item.Token0, item.Token1, 0, 0)
+	if err != nil {
+		return err
+	}
+	levels := make([]*db.Depth, 0, len(depth.Levels))
+	for _, level := range depth.Levels {
+		// buying token0 pays in token1 while selling pays in token0
+		amount0, amount1 := level.AmountOut, level.AmountIn
+		if level.Move < 0 {
+			amount0, amount1 = level.AmountIn, level.AmountOut
+		}
+		record := &db.Depth{Token0: item.Token0, Token1: item.Token1, Move: level.Move}
+		record.Amount0, _ = utils.ToDecimal(amount0, depth.Token0.Decimals).Float64()
+		record.Amount1, _ = utils.ToDecimal(amount1, depth.Token1.Decimals).Float64()
+		levels = append(levels, record)
+	}
+	if len(levels) == 0 {
+		return nil
+	}
+	return d.db.RecordDepth(levels)
+}
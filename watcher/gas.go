@@ -0,0 +1,77 @@
+package watcher
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/utils"
+)
+
+// GasTracker periodically records the gas fees suggested for the pending block
+type GasTracker struct {
+	wg     *sync.WaitGroup
+	bc     *bclient.Client
+	db     *db.Database
+	ctx    context.Context
+	cancel context.CancelFunc
+	period time.Duration
+	// last is the newest block fees were recorded for, so blocks aren't recorded twice
+	last     uint64
+	recorded bool
+}
+
+// NewGasTracker returns a tracker recording the fees of the client's chain every tick
+func NewGasTracker(ctx context.Context, bc *bclient.Client, db *db.Database, tick time.Duration) *GasTracker {
+	ctx, cancel := context.WithCancel(ctx)
+	return &GasTracker{wg: &sync.WaitGroup{}, bc: bc, db: db, ctx: ctx, cancel: cancel, period: tick}
+}
+
+// Start begins recording fees every tick
+func (g *GasTracker) Start() {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		ticker := time.NewTicker(g.period)
+		defer ticker.Stop()
+		for {
+			if err := g.record(); err != nil {
+				log.Printf("failed to record gas fees: %s\n", err)
+			}
+			select {
+			case <-g.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops recording fees
+func (g *GasTracker) Stop() {
+	g.cancel()
+	g.wg.Wait()
+}
+
+func (g *GasTracker) record() error {
+	fees, err := g.bc.GasFees()
+	if err != nil {
+		return err
+	}
+	if g.recorded && fees.Block == g.last {
+		return nil
+	}
+	price := &db.GasPrice{ChainID: g.bc.Network().ChainID, Block: fees.Block}
+	price.BaseFee, _ = utils.ToDecimal(fees.BaseFee, 9).Float64()
+	price.SlowTip, _ = utils.ToDecimal(fees.SlowTip, 9).Float64()
+	price.StandardTip, _ = utils.ToDecimal(fees.StandardTip, 9).Float64()
+	price.FastTip, _ = utils.ToDecimal(fees.FastTip, 9).Float64()
+	if err := g.db.RecordGasPrice(price); err != nil {
+		return err
+	}
+	g.last, g.recorded = fees.Block, true
+	return nil
+}
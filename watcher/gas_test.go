@@ -0,0 +1,38 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasTracker(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	h, err := harness.New()
+	require.NoError(t, err)
+	bc := h.Client()
+	defer bc.Close()
+	database, err := db.New(&db.Opts{Type: "sqlite", DBName: "indexed"})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate())
+
+	tracker := NewGasTracker(context.Background(), bc, database, 0)
+	require.NoError(t, tracker.record())
+	price, err := database.LastGasPrice(bc.Network().ChainID)
+	require.NoError(t, err)
+	// the simulated backend has no fee market so its gas price is used as the base fee
+	require.Greater(t, price.BaseFee, float64(0))
+	require.Zero(t, price.StandardTip)
+
+	// the same block isn't recorded twice
+	require.NoError(t, tracker.record())
+	last, err := database.LastGasPrice(bc.Network().ChainID)
+	require.NoError(t, err)
+	require.Equal(t, price.ID, last.ID)
+}
@@ -0,0 +1,140 @@
+package watcher
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bonedaddy/unibot/db"
+)
+
+var (
+	// leaderState is 1 for every role this process leads and 0 for roles it is a standby for
+	leaderState = expvar.NewMap("leader")
+	// leaderTransitions counts how often this process gained or lost leadership of each role
+	leaderTransitions = expvar.NewMap("leader_transitions")
+)
+
+// Leader runs a role only while holding its leadership lock. Every interval the leader renews the
+// lock and standbys try to acquire it, so a standby takes over within the lock's expiry plus interval
+// of the leader dying
+type Leader struct {
+	name     string
+	lock     db.Lock
+	interval time.Duration
+	// elected starts the role, and if it fails the lock is released for another process to try
+	elected func() error
+	demoted func()
+	leading int32
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// NewLeader returns a leader for the named role, calling elected when leadership is gained and
+// demoted when it is lost or the leader is stopped
+func NewLeader(ctx context.Context, name string, lock db.Lock, interval time.Duration, elected func() error, demoted func()) *Leader {
+	ctx, cancel := context.WithCancel(ctx)
+	leaderState.Set(name, new(expvar.Int))
+	return &Leader{
+		name:     name,
+		lock:     lock,
+		interval: interval,
+		elected:  elected,
+		demoted:  demoted,
+		ctx:      ctx,
+		cancel:   cancel,
+		wg:       &sync.WaitGroup{},
+	}
+}
+
+// IsLeader returns whether this process currently leads the role
+func (l *Leader) IsLeader() bool {
+	return atomic.LoadInt32(&l.leading) == 1
+}
+
+// Start campaigns for leadership in the background
+func (l *Leader) Start() {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		ticker := time.NewTicker(l.interval)
+		defer ticker.Stop()
+		log.Printf("campaigning for leadership of %s\n", l.name)
+		for {
+			l.campaign()
+			select {
+			case <-l.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// campaign acquires or renews the lock, starting or stopping the role when leadership changes
+func (l *Leader) campaign() {
+	ctx, cancel := context.WithTimeout(l.ctx, l.interval)
+	defer cancel()
+	held, err := l.lock.TryAcquire(ctx)
+	if l.ctx.Err() != nil {
+		// stopping, which steps down if leading
+		return
+	}
+	if err != nil {
+		log.Printf("failed to acquire leadership of %s: %s\n", l.name, err)
+	}
+	switch {
+	case held && !l.IsLeader():
+		log.Printf("elected leader of %s\n", l.name)
+		if err := l.elected(); err != nil {
+			log.Printf("failed to start %s, stepping down: %s\n", l.name, err)
+			if err := l.lock.Release(ctx); err != nil {
+				log.Printf("failed to release leadership of %s: %s\n", l.name, err)
+			}
+			return
+		}
+		l.setLeading(true)
+	case !held && l.IsLeader():
+		log.Printf("lost leadership of %s\n", l.name)
+		l.setLeading(false)
+		l.demoted()
+	}
+}
+
+func (l *Leader) setLeading(leading bool) {
+	var state int32
+	if leading {
+		state = 1
+	}
+	atomic.StoreInt32(&l.leading, state)
+	leaderState.Set(l.name, intVar(int64(state)))
+	leaderTransitions.Add(l.name, 1)
+}
+
+// Stop stops campaigning, stopping the role and releasing the lock if this process leads it
+func (l *Leader) Stop() {
+	l.cancel()
+	l.wg.Wait()
+	if !l.IsLeader() {
+		return
+	}
+	l.setLeading(false)
+	l.demoted()
+	ctx, cancel := context.WithTimeout(context.Background(), l.interval)
+	defer cancel()
+	if err := l.lock.Release(ctx); err != nil {
+		log.Printf("failed to release leadership of %s: %s\n", l.name, err)
+	}
+	log.Printf("released leadership of %s\n", l.name)
+}
+
+func intVar(v int64) *expvar.Int {
+	i := new(expvar.Int)
+	i.Set(v)
+	return i
+}
@@ -0,0 +1,118 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLock is held while held is true
+type fakeLock struct {
+	mux      sync.Mutex
+	held     bool
+	released bool
+}
+
+func (l *fakeLock) set(held bool) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.held = held
+}
+
+func (l *fakeLock) wasReleased() bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	released := l.released
+	l.released = false
+	return released
+}
+
+func (l *fakeLock) TryAcquire(context.Context) (bool, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.held, nil
+}
+
+func (l *fakeLock) Release(context.Context) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.released = true
+	return nil
+}
+
+func TestLeader(t *testing.T) {
+	lock := &fakeLock{}
+	var (
+		mux      sync.Mutex
+		running  bool
+		failNext bool
+		starts   int
+	)
+	elected := func() error {
+		mux.Lock()
+		defer mux.Unlock()
+		starts++
+		if failNext {
+			failNext = false
+			return errors.New("failed to start")
+		}
+		running = true
+		return nil
+	}
+	demoted := func() {
+		mux.Lock()
+		defer mux.Unlock()
+		running = false
+	}
+	isRunning := func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return running
+	}
+	transitions := func() int64 {
+		if v, ok := leaderTransitions.Get("test").(*expvar.Int); ok {
+			return v.Value()
+		}
+		return 0
+	}
+	initial := transitions()
+	leader := NewLeader(context.Background(), "test", lock, time.Millisecond*10, elected, demoted)
+	leader.Start()
+
+	// standbys don't run the role
+	time.Sleep(time.Millisecond * 50)
+	require.False(t, leader.IsLeader())
+	require.False(t, isRunning())
+
+	lock.set(true)
+	require.Eventually(t, leader.IsLeader, time.Second, time.Millisecond*10)
+	require.True(t, isRunning())
+	require.Equal(t, "1", leaderState.Get("test").String())
+
+	lock.set(false)
+	require.Eventually(t, func() bool { return !leader.IsLeader() }, time.Second, time.Millisecond*10)
+	require.False(t, isRunning())
+	require.Equal(t, "0", leaderState.Get("test").String())
+
+	// failing to start the role releases the lock and tries again later
+	mux.Lock()
+	failNext = true
+	mux.Unlock()
+	lock.set(true)
+	require.Eventually(t, leader.IsLeader, time.Second, time.Millisecond*10)
+	mux.Lock()
+	require.GreaterOrEqual(t, starts, 3)
+	mux.Unlock()
+	require.True(t, lock.wasReleased())
+
+	leader.Stop()
+	require.False(t, leader.IsLeader())
+	require.False(t, isRunning())
+	require.True(t, lock.wasReleased())
+	require.Equal(t, initial+4, transitions())
+}
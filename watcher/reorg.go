@@ -0,0 +1,196 @@
+package watcher
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// headerReader reads the headers of a chain
+type headerReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// ReorgTracker keeps the hashes of the recent blocks of a chain to detect reorgs. When blocks are
+// reorganized out of the chain the rows recorded from them are rolled back, and once a block is
+// confirmations deep the rows recorded from it are marked final
+type ReorgTracker struct {
+	wg      *sync.WaitGroup
+	headers headerReader
+	chainID uint64
+	db      *db.Database
+	ctx     context.Context
+	cancel  context.CancelFunc
+	period  time.Duration
+	// confirmations is how deep a block must be before it is final
+	confirmations uint64
+	// reorged is called with the common ancestor once rows were rolled back, so they can be
+	// ingested again from the new chain
+	reorged func(ancestor uint64)
+	// blocks are the tracked blocks that aren't final yet, oldest first
+	blocks []db.Block
+}
+
+// NewReorgTracker returns a tracker of the client's chain checking for reorgs every tick
+func NewReorgTracker(ctx context.Context, bc *bclient.Client, db *db.Database, confirmations uint64, tick time.Duration, reorged func(ancestor uint64)) *ReorgTracker {
+	ctx, cancel := context.WithCancel(ctx)
+	return &ReorgTracker{
+		wg:            &sync.WaitGroup{},
+		headers:       bc.Backend(),
+		chainID:       bc.Network().ChainID,
+		db:            db,
+		ctx:           ctx,
+		cancel:        cancel,
+		period:        tick,
+		confirmations: confirmations,
+		reorged:       reorged,
+	}
+}
+
+// Start resumes tracking the blocks recorded by a previous run and begins checking for reorgs
+// every tick
+func (t *ReorgTracker) Start() {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		blocks, err := t.db.Blocks(t.chainID)
+		if err != nil {
+			log.Printf("failed to load recent blocks of chain %d: %s\n", t.chainID, err)
+		}
+		t.blocks = blocks
+		ticker := time.NewTicker(t.period)
+		defer ticker.Stop()
+		for {
+			if err := t.sync(); err != nil {
+				log.Printf("failed to check chain %d for reorgs: %s\n", t.chainID, err)
+			}
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops checking for reorgs
+func (t *ReorgTracker) Stop() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+// sync rolls back the tracked blocks no longer in the chain, then tracks the blocks up to the
+// head and finalizes those that are deep enough
+func (t *ReorgTracker) sync() error {
+	head, err := t.headers.HeaderByNumber(t.ctx, nil)
+	if err != nil {
+		return err
+	}
+	number := head.Number.Uint64()
+	from := uint64(0)
+	if number > t.confirmations {
+		from = number - t.confirmations
+	}
+	if len(t.blocks) > 0 {
+		top := t.blocks[len(t.blocks)-1].Number
+		if number < top {
+			// the node is behind, a reorg onto a shorter chain is detected once it grows past the top
+			return nil
+		}
+		ancestor, err := t.ancestor()
+		if err != nil {
+			return err
+		}
+		if ancestor < top {
+			// rows recorded from the new chain's blocks before this sync are kept
+			var canonical []string
+			for n := ancestor + 1; n <= number; n++ {
+				header := head
+				if n < number {
+					if header, err = t.headers.HeaderByNumber(t.ctx, new(big.Int).SetUint64(n)); err != nil {
+						return err
+					}
+				}
+				canonical = append(canonical, header.Hash().String())
+			}
+			if err := t.rollback(ancestor, canonical); err != nil {
+				return err
+			}
+		}
+		if ancestor+1 > from {
+			from = ancestor + 1
+		}
+	}
+	for n := from; n <= number; n++ {
+		header := head
+		if n < number {
+			if header, err = t.headers.HeaderByNumber(t.ctx, new(big.Int).SetUint64(n)); err != nil {
+				return err
+			}
+		}
+		block := db.Block{ChainID: t.chainID, Number: n, Hash: header.Hash().String(), ParentHash: header.ParentHash.String()}
+		if prev := len(t.blocks) - 1; prev >= 0 && t.blocks[prev].Number == n-1 && t.blocks[prev].Hash != block.ParentHash {
+			// the chain reorganized while syncing, which the next sync rolls back
+			return nil
+		}
+		if err := t.db.RecordBlock(&block); err != nil {
+			return err
+		}
+		t.blocks = append(t.blocks, block)
+	}
+	if number < t.confirmations || len(t.blocks) == 0 || t.blocks[0].Number > number-t.confirmations {
+		return nil
+	}
+	final := number - t.confirmations
+	if err := t.db.Finalize(t.chainID, final); err != nil {
+		return err
+	}
+	for len(t.blocks) > 0 && t.blocks[0].Number <= final {
+		t.blocks = t.blocks[1:]
+	}
+	return nil
+}
+
+// ancestor returns the newest tracked block still in the chain. If none are the reorg is deeper
+// than the confirmations, and the block before the oldest tracked one is assumed to be
+func (t *ReorgTracker) ancestor() (uint64, error) {
+	for i := len(t.blocks) - 1; i >= 0; i-- {
+		header, err := t.headers.HeaderByNumber(t.ctx, new(big.Int).SetUint64(t.blocks[i].Number))
+		if err != nil {
+			return 0, err
+		}
+		if header.Hash().String() == t.blocks[i].Hash {
+			return t.blocks[i].Number, nil
+		}
+	}
+	if t.blocks[0].Number == 0 {
+		return 0, nil
+	}
+	log.Printf("reorg of chain %d is deeper than %d confirmations, rows before block %d can't be rolled back\n",
+		t.chainID, t.confirmations, t.blocks[0].Number)
+	return t.blocks[0].Number - 1, nil
+}
+
+// rollback deletes the rows recorded from the blocks after the ancestor other than the canonical
+// blocks and stops tracking them
+func (t *ReorgTracker) rollback(ancestor uint64, canonical []string) error {
+	rollback, err := t.db.RollbackTo(t.chainID, ancestor, canonical...)
+	if err != nil {
+		return err
+	}
+	log.Printf("chain %d reorganized after block %d, rolled back %d prices %d swaps %d arbitrages %d pairs\n",
+		t.chainID, ancestor, rollback.Prices, rollback.Swaps, rollback.Arbitrages, rollback.Pairs)
+	for len(t.blocks) > 0 && t.blocks[len(t.blocks)-1].Number > ancestor {
+		t.blocks = t.blocks[:len(t.blocks)-1]
+	}
+	if t.reorged != nil {
+		t.reorged(ancestor)
+	}
+	return nil
+}
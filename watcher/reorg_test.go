@@ -0,0 +1,127 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/bonedaddy/unibot/db"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain is a chain of headers that can be reorganized
+type fakeChain struct {
+	mux     sync.Mutex
+	headers []*types.Header
+}
+
+// mine appends blocks to the chain, tagged with fork to tell forks apart
+func (c *fakeChain) mine(blocks int, fork byte) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for i := 0; i < blocks; i++ {
+		header := &types.Header{Number: big.NewInt(int64(len(c.headers))), Extra: []byte{fork}}
+		if len(c.headers) > 0 {
+			header.ParentHash = c.headers[len(c.headers)-1].Hash()
+		}
+		c.headers = append(c.headers, header)
+	}
+}
+
+// reorg replaces the blocks after ancestor with blocks of another fork
+func (c *fakeChain) reorg(ancestor uint64, blocks int, fork byte) {
+	c.mux.Lock()
+	c.headers = c.headers[:ancestor+1]
+	c.mux.Unlock()
+	c.mine(blocks, fork)
+}
+
+func (c *fakeChain) hash(number uint64) string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.headers[number].Hash().String()
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, errors.New("not found")
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func TestReorgTracker(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	database, err := db.New(&db.Opts{Type: "sqlite", DBName: "indexed"})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate())
+	chain := &fakeChain{}
+	chain.mine(10, 0)
+	var reorged []uint64
+	tracker := &ReorgTracker{
+		headers:       chain,
+		chainID:       1,
+		db:            database,
+		ctx:           context.Background(),
+		confirmations: 3,
+		reorged:       func(ancestor uint64) { reorged = append(reorged, ancestor) },
+	}
+	record := func(number uint64) {
+		require.NoError(t, database.RecordObservation(&db.Price{Token0: "a", Token1: "b", ChainID: 1, Block: number, BlockHash: chain.hash(number)}))
+	}
+
+	// blocks older than the confirmations are final from the start
+	require.NoError(t, tracker.sync())
+	require.Len(t, tracker.blocks, 3)
+	require.Equal(t, uint64(7), tracker.blocks[0].Number)
+
+	record(8)
+	record(9)
+	// block 10 is orphaned before the tracker sees it
+	chain.mine(1, 0)
+	record(10)
+	chain.reorg(8, 2, 1)
+	record(10)
+	require.NoError(t, tracker.sync())
+	require.Equal(t, []uint64{8}, reorged)
+	// the prices from the orphaned blocks 9 and 10 are rolled back
+	prices, err := database.GetAllPrices("a", "b")
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	require.Equal(t, uint64(8), prices[0].Block)
+	require.False(t, prices[0].Final)
+	require.Equal(t, uint64(10), prices[1].Block)
+	require.Len(t, tracker.blocks, 3)
+	require.Equal(t, chain.hash(10), tracker.blocks[2].Hash)
+
+	// block 8 is final once 3 blocks were mined on top of it
+	chain.mine(1, 1)
+	require.NoError(t, tracker.sync())
+	prices, err = database.GetAllPrices("a", "b")
+	require.NoError(t, err)
+	require.True(t, prices[0].Final)
+	require.False(t, prices[1].Final)
+
+	// a reorg deeper than the confirmations rolls back every tracked block
+	chain.reorg(5, 6, 2)
+	require.NoError(t, tracker.sync())
+	require.Equal(t, []uint64{8, 8}, reorged)
+	prices, err = database.GetAllPrices("a", "b")
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+
+	// a resumed tracker picks up the blocks tracked before
+	blocks, err := database.Blocks(1)
+	require.NoError(t, err)
+	require.Equal(t, tracker.blocks, blocks)
+}
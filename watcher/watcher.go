@@ -2,78 +2,480 @@ package watcher
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bonedaddy/unibot/bclient"
 	"github.com/bonedaddy/unibot/db"
 	"github.com/bonedaddy/unibot/discord"
-	"github.com/bonedaddy/unibot/utils"
+	"github.com/bonedaddy/unibot/health"
+	"github.com/bonedaddy/unibot/pricing"
+	"github.com/ethereum/go-ethereum/common"
 )
 
-// Service provides a price watcher service that updates a database
+// Service provides a price watcher service that updates a database. Every item is priced on its
+// own interval by a bounded pool of workers, backing off exponentially while its source fails
 type Service struct {
 	wg     *sync.WaitGroup
 	db     *db.Database
-	bc     *bclient.Client
+	conv   *pricing.Converter
 	ctx    context.Context
 	cancel context.CancelFunc
-	period time.Duration
-	items  []WatchItem
+	opts   Opts
+
+	// imux guards the schedules of the items and the random source used for jitter
+	imux      sync.Mutex
+	schedules []*schedule
+	rand      *rand.Rand
+	// wake interrupts the scheduler when items are added or finish being priced
+	wake chan struct{}
+
+	// smux guards the channels of subscribers to recorded prices
+	smux sync.Mutex
+	subs []chan *db.Price
+}
+
+// Opts configures the scheduling of a watcher service
+type Opts struct {
+	// Interval is how often items without their own interval are priced, defaults to 5s
+	Interval time.Duration
+	// Workers is how many items are priced concurrently, defaults to 4
+	Workers int
+	// Jitter is the fraction intervals are randomly shortened or lengthened by, defaults to 0.1
+	Jitter float64
+	// MaxBackoff caps how long an item whose source keeps failing waits between attempts, defaults to 10m
+	MaxBackoff time.Duration
+	// StaleAfter is how long an item can go without a successful update before it is unhealthy,
+	// defaults to 5m. Unchanged prices are recorded again after half of it, so the age of the
+	// last recorded price shows whether it is stale
+	StaleAfter time.Duration
+}
+
+func (o Opts) withDefaults() Opts {
+	if o.Interval <= 0 {
+		o.Interval = time.Second * 5
+	}
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+	if o.Jitter <= 0 {
+		o.Jitter = 0.1
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Minute * 10
+	}
+	if o.StaleAfter <= 0 {
+		o.StaleAfter = time.Minute * 5
+	}
+	return o
 }
 
 type WatchItem struct {
-	Token0   string
-	Token1   string
-	Decimals int
+	Token0 string
+	Token1 string
+	Source pricing.PriceSource
+	// Conv converts prices on networks other than the service's, if nil the service's converter is used
+	Conv *pricing.Converter
+	// Interval is how often the item is priced, if zero the service's interval is used
+	Interval time.Duration
+	// Client reads the block each price is read at, so prices can be rolled back if the block is
+	// reorganized out of the chain. If nil blocks aren't recorded
+	Client *bclient.Client
+}
+
+// schedule tracks when an item is next priced
+type schedule struct {
+	item     WatchItem
+	next     time.Time
+	running  bool
+	failures int
+	// last is the last price recorded, which isn't recorded again until it changes
+	last *db.Price
+	// updated is when the item was last priced successfully, or added if it hasn't been yet,
+	// and block the block it was priced at
+	updated time.Time
+	block   uint64
+	err     error
+	// resync is set when the item's recorded prices were rolled back, so the price is recorded
+	// again even if unchanged
+	resync bool
 }
 
-func ConfigToWatchItmes(cfg *discord.Config) []WatchItem {
+// ConfigToWatchItmes returns the watch items for the configured watchers. Each watcher is priced
+// using the client connected to its network, returning an error if none of the clients are
+func ConfigToWatchItmes(cfg *discord.Config, clients ...*bclient.Client) ([]WatchItem, error) {
 	items := make([]WatchItem, 0, len(cfg.Watchers))
 	for _, watch := range cfg.Watchers {
-		items = append(items, WatchItem{watch.Token0Address, watch.Token1Address, watch.Decimals})
+		net, err := cfg.WatcherNetwork(watch)
+		if err != nil {
+			return nil, err
+		}
+		var bc *bclient.Client
+		for _, client := range clients {
+			if client.Network().ChainID == net.ChainID {
+				bc = client
+				break
+			}
+		}
+		if bc == nil {
+			return nil, fmt.Errorf("no client connected to %s for pair %s", net.Name, watch.Pair)
+		}
+		source, err := NewPriceSource(bc, watch)
+		if err != nil {
+			return nil, err
+		}
+		item := WatchItem{Token0: watch.Token0Address, Token1: watch.Token1Address, Source: source, Interval: watch.Interval, Client: bc}
+		if bc != clients[0] {
+			// the configured anchors are for the default network so use the network's stablecoins
+			item.Conv = pricing.NewConverter(bc, nil)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// NewPriceSource returns the price source selected by the watcher config
+func NewPriceSource(bc *bclient.Client, watch discord.Watcher) (pricing.PriceSource, error) {
+	uniswap := pricing.NewUniswapSource(bc, watch.Token0Address, watch.Token1Address)
+	switch strings.ToLower(watch.Source) {
+	case "", "uniswap":
+		return uniswap, nil
+	case "uniswap-v3":
+		fee := watch.FeeTier
+		if fee == 0 {
+			fee = 3000
+		}
+		return pricing.NewUniswapV3Source(bc, watch.Token0Address, watch.Token1Address, fee), nil
+	case "chainlink":
+		return pricing.NewChainlinkSource(bc, common.HexToAddress(watch.ChainlinkFeed), time.Hour), nil
+	case "median":
+		chainlink := pricing.NewChainlinkSource(bc, common.HexToAddress(watch.ChainlinkFeed), time.Hour)
+		return pricing.NewMedianSource(watch.MaxDeviation, uniswap, chainlink), nil
+	default:
+		return nil, fmt.Errorf("unsupported price source %s", watch.Source)
 	}
-	return items
 }
 
 // New returns a new watcher service
-func New(ctx context.Context, db *db.Database, bc *bclient.Client, tick time.Duration, watchItems []WatchItem) *Service {
-	ctx, cancel := context.WithCancel(context.Background())
-	return &Service{&sync.WaitGroup{}, db, bc, ctx, cancel, tick, watchItems}
+func New(ctx context.Context, db *db.Database, conv *pricing.Converter, opts Opts, watchItems []WatchItem) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		wg:     &sync.WaitGroup{},
+		db:     db,
+		conv:   conv,
+		ctx:    ctx,
+		cancel: cancel,
+		opts:   opts.withDefaults(),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		wake:   make(chan struct{}, 1),
+	}
+	s.Add(watchItems...)
+	return s
+}
+
+// Add starts watching the given items. Their first prices are fetched within a fraction of
+// their interval, so items added together aren't all priced at once
+func (s *Service) Add(items ...WatchItem) {
+	s.imux.Lock()
+	defer s.imux.Unlock()
+	now := time.Now()
+	for _, item := range items {
+		log.Printf("watching token0: %s token1: %s - source: %s\n", item.Token0, item.Token1, item.Source.Name())
+		delay := time.Duration(s.rand.Float64() * s.opts.Jitter * float64(s.interval(item)))
+		s.schedules = append(s.schedules, &schedule{item: item, next: now.Add(delay), updated: now})
+	}
+	s.notify()
+}
+
+// Remove stops watching the items for the given pair, returning the number of items removed.
+// An item being priced when it is removed may still record that price
+func (s *Service) Remove(token0, token1 string) int {
+	s.imux.Lock()
+	defer s.imux.Unlock()
+	schedules := make([]*schedule, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		if strings.EqualFold(sc.item.Token0, token0) && strings.EqualFold(sc.item.Token1, token1) {
+			log.Printf("stopped watching token0: %s token1: %s - source: %s\n", sc.item.Token0, sc.item.Token1, sc.item.Source.Name())
+			continue
+		}
+		schedules = append(schedules, sc)
+	}
+	removed := len(s.schedules) - len(schedules)
+	s.schedules = schedules
+	return removed
+}
+
+// Items returns the items being watched
+func (s *Service) Items() []WatchItem {
+	s.imux.Lock()
+	defer s.imux.Unlock()
+	items := make([]WatchItem, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		items = append(items, sc.item)
+	}
+	return items
+}
+
+// Health returns the status of every item, which is unhealthy if it hasn't been priced
+// successfully within the stale threshold
+func (s *Service) Health(ctx context.Context) []health.Status {
+	s.imux.Lock()
+	defer s.imux.Unlock()
+	statuses := make([]health.Status, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		updated := sc.updated
+		status := health.Status{
+			Component: fmt.Sprintf("watcher:%s/%s:%s", sc.item.Token0, sc.item.Token1, sc.item.Source.Name()),
+			Healthy:   true,
+			Updated:   &updated,
+			Block:     sc.block,
+		}
+		if age := time.Since(updated); age > s.opts.StaleAfter {
+			status.Healthy = false
+			status.Error = fmt.Sprintf("stale, last updated %s ago", age.Round(time.Second))
+			if sc.err != nil {
+				status.Error += ": " + sc.err.Error()
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Resync prices every item as soon as possible, recording their prices even if unchanged. This is
+// used once a reorg rolled back the recorded prices
+func (s *Service) Resync() {
+	s.imux.Lock()
+	defer s.imux.Unlock()
+	now := time.Now()
+	for _, sc := range s.schedules {
+		sc.resync = true
+		if !sc.running {
+			sc.next = now
+		}
+	}
+	s.notify()
+}
+
+// Subscribe returns a channel receiving every price recorded by the service, which is closed
+// once the service stops. Prices are dropped rather than blocking the service if the subscriber
+// falls behind by more than buffer prices
+func (s *Service) Subscribe(buffer int) <-chan *db.Price {
+	s.smux.Lock()
+	defer s.smux.Unlock()
+	ch := make(chan *db.Price, buffer)
+	s.subs = append(s.subs, ch)
+	return ch
+}
+
+// publish sends the price to the subscribers without blocking
+func (s *Service) publish(price *db.Price) {
+	s.smux.Lock()
+	defer s.smux.Unlock()
+	for _, ch := range s.subs {
+		select {
+		case ch <- price:
+		default:
+		}
+	}
+}
+
+// notify wakes the scheduler without blocking
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// interval returns how often the item is priced
+func (s *Service) interval(item WatchItem) time.Duration {
+	if item.Interval > 0 {
+		return item.Interval
+	}
+	return s.opts.Interval
+}
+
+// delay returns how long to wait before pricing the item again, randomly adjusted by the jitter
+// fraction and doubled for each consecutive failure up to the maximum backoff. imux must be held
+func (s *Service) delay(sc *schedule) time.Duration {
+	delay := s.interval(sc.item)
+	for i := 0; i < sc.failures && delay < s.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.opts.MaxBackoff {
+		delay = s.opts.MaxBackoff
+	}
+	return delay + time.Duration((s.rand.Float64()*2-1)*s.opts.Jitter*float64(delay))
 }
 
 func (s *Service) Start() {
+	jobs := make(chan *schedule)
+	for i := 0; i < s.opts.Workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for sc := range jobs {
+				s.update(sc)
+			}
+		}()
+	}
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
-		ticker := time.NewTicker(s.period)
-		defer ticker.Stop()
+		defer close(jobs)
+		timer := time.NewTimer(s.opts.Interval)
+		defer timer.Stop()
 		for {
+			due, wait := s.due(time.Now())
+			for _, sc := range due {
+				select {
+				case jobs <- sc:
+				case <-s.ctx.Done():
+					return
+				}
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(wait)
 			select {
 			case <-s.ctx.Done():
 				return
-			case <-ticker.C:
-				for _, item := range s.items {
-					price, err := s.bc.GetPrice(item.Token0, item.Token1)
-					if err != nil {
-						log.Printf("failed to get price for token0: %s token1:%s - %s\n", item.Token0, item.Token1, err)
-						continue
-					}
-					log.Printf("token0: %s token1:%s - price: %v", item.Token0, item.Token1, price.Int64())
-					priceF, _ := utils.ToDecimal(price, item.Decimals).Float64()
-					if err := s.db.RecordPrice(item.Token0, item.Token1, priceF); err != nil {
-						log.Printf("failed to record price for token0: %s token1: %s - %s\n", item.Token0, item.Token1, err)
-						continue
-					}
-				}
+			case <-timer.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+// due marks the items due to be priced as running and returns them, along with how long to wait
+// until the next item is due
+func (s *Service) due(now time.Time) ([]*schedule, time.Duration) {
+	s.imux.Lock()
+	defer s.imux.Unlock()
+	var due []*schedule
+	wait := s.opts.Interval
+	for _, sc := range s.schedules {
+		if sc.running {
+			continue
+		}
+		if !now.Before(sc.next) {
+			sc.running = true
+			due = append(due, sc)
+		} else if until := sc.next.Sub(now); until < wait {
+			wait = until
+		}
+	}
+	return due, wait
+}
+
+// update prices the item and records the price unless it was recently recorded unchanged, then
+// schedules the next update
+func (s *Service) update(sc *schedule) {
+	item := sc.item
+	s.imux.Lock()
+	if sc.resync {
+		sc.resync, sc.last = false, nil
+	}
+	s.imux.Unlock()
+	var block uint64
+	err := func() error {
+		var (
+			chainID uint64
+			hash    string
+		)
+		if item.Client != nil {
+			// read before the price so the price is at least as recent as the block
+			head, err := item.Client.CurrentHeader()
+			if err != nil {
+				return fmt.Errorf("failed to get block for token0: %s token1: %s - %s", item.Token0, item.Token1, err)
 			}
+			chainID, block, hash = item.Client.Network().ChainID, head.Number.Uint64(), head.Hash().String()
+		}
+		price, err := item.Source.Price()
+		if err != nil {
+			return fmt.Errorf("failed to get price for token0: %s token1:%s - %s", item.Token0, item.Token1, err)
+		}
+		log.Printf("token0: %s token1:%s - source: %s price: %v", item.Token0, item.Token1, item.Source.Name(), price)
+		observation := s.observe(item, price)
+		observation.ChainID, observation.Block, observation.BlockHash = chainID, block, hash
+		// only the running worker accesses last
+		if sc.last != nil && samePrice(sc.last, observation) && time.Since(sc.last.CreatedAt) < s.opts.StaleAfter/2 {
+			return nil
 		}
+		if err := s.db.RecordObservation(observation); err != nil {
+			return fmt.Errorf("failed to record price for token0: %s token1: %s - %s", item.Token0, item.Token1, err)
+		}
+		sc.last = observation
+		s.publish(observation)
+		return nil
 	}()
+	s.imux.Lock()
+	defer s.imux.Unlock()
+	sc.running = false
+	sc.err = err
+	if err != nil {
+		sc.failures++
+	} else {
+		sc.failures = 0
+		sc.updated, sc.block = time.Now(), block
+	}
+	delay := s.delay(sc)
+	sc.next = time.Now().Add(delay)
+	if err != nil {
+		log.Printf("%s, retrying in %s\n", err, delay.Round(time.Millisecond))
+	}
+	s.notify()
+}
+
+// observe converts the price denominated in the item's quote token into every supported currency.
+// The native price is always recorded, while currencies the quote token can't be converted into,
+// for example because it has no pair with the wrapped native token, are left at zero
+func (s *Service) observe(item WatchItem, price float64) *db.Price {
+	quote := common.HexToAddress(item.Token1)
+	observation := &db.Price{Token0: item.Token0, Token1: item.Token1, NativePrice: price}
+	conv := s.conv
+	if item.Conv != nil {
+		conv = item.Conv
+	}
+	for _, currency := range []struct {
+		currency pricing.Currency
+		price    *float64
+	}{
+		{pricing.ETH, &observation.ETHPrice},
+		{pricing.DAI, &observation.DAIPrice},
+		{pricing.USD, &observation.USDPrice},
+	} {
+		converted, err := conv.Convert(price, quote, currency.currency)
+		if err != nil {
+			log.Printf("failed to convert price for token0: %s token1: %s to %s - %s", item.Token0, item.Token1, currency.currency, err)
+			continue
+		}
+		*currency.price = converted
+	}
+	return observation
 }
 
 func (s *Service) Stop() {
 	s.cancel()
 	s.wg.Wait()
-	return
+	s.smux.Lock()
+	for _, ch := range s.subs {
+		close(ch)
+	}
+	s.subs = nil
+	s.smux.Unlock()
+}
+
+// samePrice returns whether the observations have the same prices in every currency
+func samePrice(a, b *db.Price) bool {
+	return a.NativePrice == b.NativePrice && a.ETHPrice == b.ETHPrice && a.DAIPrice == b.DAIPrice && a.USDPrice == b.USDPrice
 }
@@ -0,0 +1,213 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bonedaddy/unibot/bclient"
+	"github.com/bonedaddy/unibot/db"
+	"github.com/bonedaddy/unibot/discord"
+	"github.com/bonedaddy/unibot/harness"
+	"github.com/bonedaddy/unibot/pricing"
+	"github.com/bonedaddy/unibot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	t.Cleanup(func() {
+		os.Remove("indexed.db")
+	})
+	h, err := harness.New(harness.MainnetTokens...)
+	require.NoError(t, err)
+	bc := h.Client()
+	defer bc.Close()
+	// ETH is worth 2000 in every anchor
+	_, err = h.SeedPools(
+		harness.Pool{TokenA: bclient.WETHTokenAddress, TokenB: bclient.DAITokenAddress, AmountA: utils.ToWei(int64(100), 18), AmountB: utils.ToWei(int64(200000), 18)},
+		harness.Pool{TokenA: bclient.WETHTokenAddress, TokenB: bclient.USDCTokenAddress, AmountA: utils.ToWei(int64(100), 18), AmountB: utils.ToWei(int64(200000), 6)},
+		harness.Pool{TokenA: bclient.WETHTokenAddress, TokenB: bclient.USDTTokenAddress, AmountA: utils.ToWei(int64(100), 18), AmountB: utils.ToWei(int64(200000), 6)},
+		// DEFI5 is worth less than one of its quote token, 0.01 WETH or 20 USD
+		harness.Pool{TokenA: bclient.DEFI5TokenAddress, TokenB: bclient.WETHTokenAddress, AmountA: utils.ToWei(int64(1000), 18), AmountB: utils.ToWei(int64(10), 18)},
+	)
+	require.NoError(t, err)
+
+	database, err := db.New(&db.Opts{Type: "sqlite", DBName: "indexed"})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate())
+	cfg := &discord.Config{Watchers: []discord.Watcher{
+		{Token0Address: bclient.WETHTokenAddress.String(), Token1Address: bclient.DAITokenAddress.String(), Pair: "WETH/DAI"},
+		{Token0Address: bclient.DEFI5TokenAddress.String(), Token1Address: bclient.WETHTokenAddress.String(), Pair: "DEFI5/WETH"},
+	}}
+	items, err := ConfigToWatchItmes(cfg, bc)
+	require.NoError(t, err)
+	failing := &testSource{err: errors.New("node unavailable")}
+	slow := &testSource{delay: time.Second}
+	items = append(items,
+		WatchItem{Token0: "failing", Token1: bclient.DAITokenAddress.String(), Source: failing},
+		// the slow item occupies one of the workers without delaying the other items
+		WatchItem{Token0: "slow", Token1: bclient.DAITokenAddress.String(), Source: slow, Interval: time.Millisecond},
+		// the quote token has no pair with WETH, so only its native price is known
+		WatchItem{Token0: "unpriced", Token1: "0x000000000000000000000000000000000000dEaD", Source: &testSource{}},
+	)
+	service := New(context.Background(), database, pricing.NewConverter(bc, nil), Opts{Interval: time.Millisecond * 50, Workers: 2}, items)
+	published := service.Subscribe(1)
+	service.Start()
+	defer service.Stop()
+
+	select {
+	case price := <-published:
+		require.Contains(t, []string{bclient.WETHTokenAddress.String(), bclient.DEFI5TokenAddress.String(), "unpriced"}, price.Token0)
+	case <-time.After(time.Second * 10):
+		t.Fatal("no price was published")
+	}
+
+	require.Eventually(t, func() bool {
+		_, err := database.LastPrice(bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String())
+		return err == nil
+	}, time.Second*10, time.Millisecond*50)
+	prices, err := database.GetAllPrices(bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String())
+	require.NoError(t, err)
+	require.InDelta(t, 2000, prices[0].NativePrice, 0.0001)
+	require.InDelta(t, 1, prices[0].ETHPrice, 0.0001)
+	require.InDelta(t, 2000, prices[0].DAIPrice, 0.01)
+	require.InDelta(t, 2000, prices[0].USDPrice, 0.01)
+	require.NotZero(t, prices[0].Block)
+
+	require.Eventually(t, func() bool {
+		_, err := database.LastPrice(bclient.DEFI5TokenAddress.String(), bclient.WETHTokenAddress.String())
+		return err == nil
+	}, time.Second*10, time.Millisecond*50)
+	prices, err = database.GetAllPrices(bclient.DEFI5TokenAddress.String(), bclient.WETHTokenAddress.String())
+	require.NoError(t, err)
+	require.InDelta(t, 0.01, prices[0].NativePrice, 0.000001)
+	require.InDelta(t, 0.01, prices[0].ETHPrice, 0.000001)
+	require.InDelta(t, 20, prices[0].DAIPrice, 0.01)
+	require.InDelta(t, 20, prices[0].USDPrice, 0.01)
+
+	require.Eventually(t, func() bool {
+		_, err := database.LastPrice("unpriced", "0x000000000000000000000000000000000000dEaD")
+		return err == nil
+	}, time.Second*10, time.Millisecond*50)
+	prices, err = database.GetAllPrices("unpriced", "0x000000000000000000000000000000000000dEaD")
+	require.NoError(t, err)
+	require.Equal(t, float64(1), prices[0].NativePrice)
+	require.Zero(t, prices[0].ETHPrice)
+	require.Zero(t, prices[0].USDPrice)
+
+	// the price doesn't change so it isn't recorded again, while the failing source backs off
+	time.Sleep(time.Millisecond * 500)
+	prices, err = database.GetAllPrices(bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String())
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	// without backoff it would be called about 10 times, with it about 4 (50, 100, 200, 400ms)
+	require.LessOrEqual(t, failing.Calls(), 6)
+	require.GreaterOrEqual(t, failing.Calls(), 2)
+
+	// after a reorg rolls back the recorded prices they are recorded again even if unchanged
+	service.Resync()
+	require.Eventually(t, func() bool {
+		prices, err := database.GetAllPrices(bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String())
+		return err == nil && len(prices) == 2
+	}, time.Second*10, time.Millisecond*50)
+}
+
+func TestServiceHealth(t *testing.T) {
+	failing := &testSource{err: errors.New("node unavailable")}
+	service := New(context.Background(), nil, nil, Opts{Interval: time.Millisecond * 10, StaleAfter: time.Millisecond * 200},
+		[]WatchItem{{Token0: "failing", Token1: "quote", Source: failing}})
+	service.Start()
+	defer service.Stop()
+
+	// items aren't stale until they have had time to be priced
+	statuses := service.Health(context.Background())
+	require.Len(t, statuses, 1)
+	require.Equal(t, "watcher:failing/quote:test", statuses[0].Component)
+	require.True(t, statuses[0].Healthy)
+
+	require.Eventually(t, func() bool {
+		return !service.Health(context.Background())[0].Healthy
+	}, time.Second*5, time.Millisecond*50)
+	require.Contains(t, service.Health(context.Background())[0].Error, "node unavailable")
+}
+
+func TestServiceDelay(t *testing.T) {
+	service := New(context.Background(), nil, nil, Opts{Interval: time.Second, MaxBackoff: time.Second * 10}, nil)
+	tests := []struct {
+		name     string
+		interval time.Duration
+		failures int
+		want     time.Duration
+	}{
+		{"default interval", 0, 0, time.Second},
+		{"item interval", time.Minute, 0, time.Second * 10},
+		{"backoff", 0, 3, time.Second * 8},
+		{"max backoff", 0, 10, time.Second * 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &schedule{item: WatchItem{Interval: tt.interval}, failures: tt.failures}
+			for i := 0; i < 10; i++ {
+				// jitter adjusts the delay by up to 10% either way
+				require.InEpsilon(t, float64(tt.want), float64(service.delay(sc)), 0.1)
+			}
+		})
+	}
+}
+
+// testSource returns err, or 1 after delay
+type testSource struct {
+	err   error
+	delay time.Duration
+	mux   sync.Mutex
+	calls int
+}
+
+func (s *testSource) Name() string { return "test" }
+
+func (s *testSource) Price() (float64, error) {
+	s.mux.Lock()
+	s.calls++
+	s.mux.Unlock()
+	time.Sleep(s.delay)
+	return 1, s.err
+}
+
+func (s *testSource) Calls() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.calls
+}
+
+func TestServiceSubscribe(t *testing.T) {
+	service := New(context.Background(), nil, nil, Opts{}, nil)
+	prices := service.Subscribe(1)
+	service.publish(&db.Price{Token0: "a"})
+	// prices are dropped rather than blocking when the subscriber is behind
+	service.publish(&db.Price{Token0: "b"})
+	service.Stop()
+	price, ok := <-prices
+	require.True(t, ok)
+	require.Equal(t, "a", price.Token0)
+	_, ok = <-prices
+	require.False(t, ok)
+}
+
+func TestServiceItems(t *testing.T) {
+	service := New(context.Background(), nil, nil, Opts{}, nil)
+	weth, dai := bclient.WETHTokenAddress.String(), bclient.DAITokenAddress.String()
+	service.Add(
+		WatchItem{Token0: weth, Token1: dai, Source: pricing.NewUniswapSource(nil, weth, dai)},
+		WatchItem{Token0: dai, Token1: weth, Source: pricing.NewUniswapSource(nil, dai, weth)},
+	)
+	require.Len(t, service.Items(), 2)
+	require.Equal(t, 1, service.Remove(strings.ToLower(weth), dai))
+	require.Equal(t, 0, service.Remove(weth, dai))
+	items := service.Items()
+	require.Len(t, items, 1)
+	require.Equal(t, dai, items[0].Token0)
+}